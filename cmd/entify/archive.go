@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/euskadi31/entify/pkg/builder"
+)
+
+// archiveFS is a builder.FS writing an archive file, closed once all the files are generated.
+type archiveFS interface {
+	builder.FS
+
+	Close() error
+}
+
+type archiveFile struct {
+	archiveFS
+
+	f *os.File
+}
+
+func (a *archiveFile) Close() error {
+	if err := a.archiveFS.Close(); err != nil {
+		a.f.Close()
+
+		return err
+	}
+
+	if err := a.f.Close(); err != nil {
+		return fmt.Errorf("close %s file failed: %w", a.f.Name(), err)
+	}
+
+	return nil
+}
+
+// openArchive creates the archive file, its format depends on its extension.
+func openArchive(filename string) (archiveFS, error) {
+	var open func(f *os.File) archiveFS
+
+	switch {
+	case strings.HasSuffix(filename, ".tar.gz"), strings.HasSuffix(filename, ".tgz"):
+		open = func(f *os.File) archiveFS {
+			return builder.NewTarFS(f, true)
+		}
+	case strings.HasSuffix(filename, ".tar"):
+		open = func(f *os.File) archiveFS {
+			return builder.NewTarFS(f, false)
+		}
+	case strings.HasSuffix(filename, ".zip"):
+		open = func(f *os.File) archiveFS {
+			return builder.NewZipFS(f)
+		}
+	default:
+		return nil, fmt.Errorf("archive %s must be a .tar, .tar.gz, .tgz or .zip file", filename)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("create %s file failed: %w", filename, err)
+	}
+
+	return &archiveFile{
+		archiveFS: open(f),
+		f:         f,
+	}, nil
+}
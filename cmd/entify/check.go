@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/euskadi31/entify/pkg/builder"
+	"github.com/euskadi31/entify/pkg/spec"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check [file|dir|glob]...",
+	Short: "Check the generated entities are up to date with the HCL spec files",
+	Args:  cobra.ArbitraryArgs,
+	RunE:  checkRun,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+}
+
+// errOutdated is returned by the check command when a generated file is not up to date, main exits with 1.
+var errOutdated = errors.New("generated files are not up to date")
+
+// checkRun generates the entities in memory and returns errOutdated when a file differs from the out directory.
+func checkRun(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		args = project.Specs
+	}
+
+	if len(args) == 0 {
+		return cmd.Usage()
+	}
+
+	loader := spec.New()
+
+	realm, err := loader.ParseFiles(stringSetting(cmd, "provider", providerFlag, project.Provider), args...)
+	if err != nil {
+		return fmt.Errorf("open spec file failed: %w", err)
+	}
+
+	items, err := targets(cmd, realm)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+
+	for _, t := range items {
+		changes, err := t.changes()
+		if err != nil {
+			return fmt.Errorf("check entity files failed: %w", err)
+		}
+
+		for _, change := range changes {
+			switch change.Kind {
+			case builder.ChangeCreate:
+				fmt.Fprintf(cmd.OutOrStdout(), "missing %s\n", path.Join(t.dir, change.Name))
+			case builder.ChangeModify:
+				fmt.Fprintf(cmd.OutOrStdout(), "outdated %s\n", path.Join(t.dir, change.Name))
+			case builder.ChangeDelete:
+				fmt.Fprintf(cmd.OutOrStdout(), "stale %s\n", path.Join(t.dir, change.Name))
+			}
+		}
+
+		count += len(changes)
+	}
+
+	if count > 0 {
+		log.Error().Msgf("%d generated file(s) are not up to date, run entify generate", count)
+
+		return errOutdated
+	}
+
+	log.Info().Msg("generated files are up to date")
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/euskadi31/entify/pkg/builder"
+	"github.com/euskadi31/entify/pkg/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRun(t *testing.T) {
+	dir := t.TempDir()
+	entities := filepath.Join(dir, "entity")
+	specFile := filepath.Join(dir, "schema.hcl")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/acme/app\n"), 0600))
+	assert.NoError(t, os.WriteFile(specFile, []byte(`
+table "users" {
+  schema = schema.main
+
+  column "id" {
+    null = false
+    type = integer
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+}
+
+schema "main" {
+}
+`), 0600))
+
+	defer func(output string, provider string) {
+		outputFlag, providerFlag = output, provider
+	}(outputFlag, providerFlag)
+
+	outputFlag, providerFlag = entities, "sqlite"
+
+	check := func() (string, error) {
+		buf := bytes.NewBuffer(nil)
+
+		checkCmd.SetOut(buf)
+		defer checkCmd.SetOut(nil)
+
+		err := checkRun(checkCmd, []string{specFile})
+
+		return buf.String(), err
+	}
+
+	output, err := check()
+	assert.ErrorIs(t, err, errOutdated)
+	assert.Contains(t, output, "missing "+filepath.ToSlash(filepath.Join(entities, "user.go"))+"\n")
+
+	realm, err := spec.New().ParseFiles("sqlite", specFile)
+	assert.NoError(t, err)
+
+	items, err := targets(checkCmd, realm)
+	assert.NoError(t, err)
+
+	for _, item := range items {
+		_, err := item.build(nil)
+		assert.NoError(t, err)
+	}
+
+	output, err = check()
+	assert.NoError(t, err)
+	assert.Empty(t, output)
+
+	user := filepath.Join(entities, "user.go")
+
+	content, err := os.ReadFile(user)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(user, append(content, []byte("\n// edited\n")...), 0600))
+
+	assert.NoError(t, os.Remove(filepath.Join(entities, "user_client.go")))
+	assert.NoError(t, os.WriteFile(filepath.Join(entities, "post.go"), []byte(builder.GeneratedHeader+", DO NOT EDIT.\n\npackage entity\n"), 0600))
+
+	output, err = check()
+	assert.ErrorIs(t, err, errOutdated)
+	assert.Contains(t, output, "outdated "+filepath.ToSlash(user)+"\n")
+	assert.Contains(t, output, "missing "+filepath.ToSlash(filepath.Join(entities, "user_client.go"))+"\n")
+	assert.Contains(t, output, "stale "+filepath.ToSlash(filepath.Join(entities, "post.go"))+"\n")
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/euskadi31/entify/pkg/builder"
+	"github.com/euskadi31/entify/pkg/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// project is the config file of the project, empty when there is none.
+var project = &config.Config{}
+
+// loadConfig reads the config file given by the config flag or discovered in the working directory.
+func loadConfig(cmd *cobra.Command, args []string) error {
+	filename := configFlag
+
+	if filename == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("get working dir failed: %w", err)
+		}
+
+		filename, err = config.Find(wd)
+		if err != nil {
+			return err
+		}
+
+		if filename == "" {
+			return nil
+		}
+	}
+
+	cfg, err := config.Load(filename)
+	if err != nil {
+		return err
+	}
+
+	log.Debug().Msgf("use %s config file", filename)
+
+	project = cfg
+
+	return nil
+}
+
+// stringSetting returns the flag value when it is set on the command line, the file value otherwise.
+func stringSetting(cmd *cobra.Command, name string, flag string, file string) string {
+	if cmd.Flags().Changed(name) || file == "" {
+		return flag
+	}
+
+	return file
+}
+
+// builderConfig returns the config file settings overridden by the flags set on the command line.
+func builderConfig(cmd *cobra.Command) (builder.Config, error) {
+	cfg := builder.DefaultConfig()
+
+	project.Apply(&cfg)
+
+	flags := cmd.Flags()
+
+	if flags.Changed("created-at") {
+		cfg.CreatedAtColumn = createdAtColumnFlag
+	}
+
+	if flags.Changed("updated-at") {
+		cfg.UpdatedAtColumn = updatedAtColumnFlag
+	}
+
+	if flags.Changed("deleted-at") {
+		cfg.DeletedAtColumn = deletedAtColumnFlag
+	}
+
+	if flags.Changed("version-column") {
+		cfg.VersionColumn = versionColumnFlag
+	}
+
+	if flags.Changed("nullable") {
+		cfg.NullableMode = builder.NullableMode(nullableFlag)
+	}
+
+	if flags.Changed("column-order") {
+		cfg.ColumnOrder = builder.ColumnOrder(columnOrderFlag)
+	}
+
+	if flags.Changed("include") {
+		cfg.IncludeTables = includeFlag
+	}
+
+	if flags.Changed("exclude") {
+		cfg.ExcludeTables = excludeFlag
+	}
+
+	if typesFlag != "" {
+		overrides, err := builder.LoadTypeOverrides(typesFlag)
+		if err != nil {
+			return cfg, fmt.Errorf("load type overrides failed: %w", err)
+		}
+
+		cfg.TypeOverrides = overrides
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/euskadi31/entify/pkg/builder"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dryRunFlag  bool
+	diffFlag    bool
+	workersFlag int
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate [file|dir|glob]...",
+	Short: "Generate the entities from HCL spec files",
+	Args:  cobra.ArbitraryArgs,
+	RunE:  builderRun,
+}
+
+func init() {
+	// the root command generates the entities too, the flags are not inherited by the other commands.
+	for _, cmd := range []*cobra.Command{rootCmd, generateCmd} {
+		cmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "list the files that would be created, modified or deleted without writing them")
+		cmd.Flags().BoolVar(&diffFlag, "diff", false, "print the unified diff of the files that would change without writing them")
+	}
+
+	rootCmd.PersistentFlags().IntVarP(&workersFlag, "workers", "j", 0, "number of templates rendered concurrently (default is the number of CPUs)")
+
+	rootCmd.AddCommand(generateCmd)
+}
+
+// target is the output of a schema.
+type target struct {
+	schema *schema.Schema
+
+	// dir is the output dir of the schema.
+	dir string
+
+	// sub is the dir of the schema in the archive, empty for the root.
+	sub string
+
+	opts []builder.Option
+}
+
+// targets returns the output of the schemas,
+// a single schema is generated in the out directory, each schema in its own package otherwise.
+func targets(cmd *cobra.Command, realm schema.Realm) ([]*target, error) {
+	dest := stringSetting(cmd, "out", outputFlag, project.Output)
+	pkg := stringSetting(cmd, "package", packageFlag, project.Package)
+
+	config, err := builderConfig(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	if len(realm.Schemas) == 0 {
+		return nil, fmt.Errorf("no schema found")
+	}
+
+	opts := []builder.Option{
+		builder.WithConfig(config),
+		builder.WithWorkers(workersFlag),
+	}
+
+	if dir := stringSetting(cmd, "templates", templatesFlag, project.Templates); dir != "" {
+		opts = append(opts, builder.WithTemplates(os.DirFS(dir)))
+	}
+
+	items := make([]*target, 0, len(realm.Schemas))
+
+	for _, s := range realm.Schemas {
+		t := &target{
+			schema: s,
+			dir:    dest,
+			opts:   append([]builder.Option(nil), opts...),
+		}
+
+		if len(realm.Schemas) == 1 {
+			if pkg != "" {
+				t.opts = append(t.opts, builder.WithPackage(pkg))
+			}
+		} else {
+			t.sub = builder.SchemaNameToPackageName(s.Name)
+			t.dir = path.Join(dest, t.sub)
+			t.opts = append(t.opts, builder.WithPackage(t.sub))
+		}
+
+		items = append(items, t)
+	}
+
+	return items, nil
+}
+
+// build generates the entities of the target in fsys, in its output dir when fsys is nil.
+func (t *target) build(fsys builder.FS) (builder.Stats, error) {
+	opts := append([]builder.Option{builder.WithOutput(t.dir)}, t.opts...)
+
+	if fsys != nil {
+		opts = append(opts, builder.WithFS(fsys))
+	}
+
+	b := builder.New(*t.schema, opts...)
+
+	if err := b.Build(); err != nil {
+		return builder.Stats{}, fmt.Errorf("generate %s schema failed: %w", t.schema.Name, err)
+	}
+
+	return b.Stats(), nil
+}
+
+// changes generates the entities of the target in memory and compares them with its output dir.
+func (t *target) changes() ([]*builder.Change, error) {
+	files := builder.NewMemFS()
+
+	if _, err := t.build(files); err != nil {
+		return nil, err
+	}
+
+	changes, err := builder.Diff(t.dir, files)
+	if err != nil {
+		return nil, fmt.Errorf("compare %s schema failed: %w", t.schema.Name, err)
+	}
+
+	return changes, nil
+}
+
+// generate builds the entities of the schemas.
+func generate(cmd *cobra.Command, realm schema.Realm) error {
+	items, err := targets(cmd, realm)
+	if err != nil {
+		return fmt.Errorf("generate entity files failed: %w", err)
+	}
+
+	if dryRunFlag || diffFlag {
+		return preview(cmd, items)
+	}
+
+	var archive archiveFS
+
+	if archiveFlag != "" {
+		archive, err = openArchive(archiveFlag)
+		if err != nil {
+			return fmt.Errorf("open archive failed: %w", err)
+		}
+	}
+
+	for _, t := range items {
+		var fsys builder.FS
+
+		if archive != nil {
+			fsys = archive
+
+			if t.sub != "" {
+				fsys = builder.SubFS(archive, t.sub)
+			}
+		}
+
+		stats, err := t.build(fsys)
+		if err != nil {
+			if archive != nil {
+				_ = archive.Close()
+			}
+
+			return fmt.Errorf("generate entity files failed: %w", err)
+		}
+
+		log.Info().
+			Str("schema", t.schema.Name).
+			Int("written", stats.Written).
+			Int("unchanged", stats.Unchanged).
+			Int("removed", stats.Removed).
+			Dur("duration", stats.Duration).
+			Msgf("%s generated", t.dir)
+	}
+
+	if archive != nil {
+		if err := archive.Close(); err != nil {
+			return fmt.Errorf("close archive failed: %w", err)
+		}
+
+		log.Info().Msgf("archive %s written", archiveFlag)
+	}
+
+	log.Info().Msg("done")
+
+	return nil
+}
+
+// preview prints the changes the generation would make, the list or the diffs of the files.
+func preview(cmd *cobra.Command, items []*target) error {
+	count := 0
+
+	for _, t := range items {
+		changes, err := t.changes()
+		if err != nil {
+			return fmt.Errorf("preview entity files failed: %w", err)
+		}
+
+		for _, change := range changes {
+			if !diffFlag || dryRunFlag {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", change.Kind, path.Join(t.dir, change.Name))
+			}
+
+			if diffFlag {
+				diff, err := change.Unified(t.dir)
+				if err != nil {
+					return fmt.Errorf("diff entity files failed: %w", err)
+				}
+
+				fmt.Fprint(cmd.OutOrStdout(), diff)
+			}
+		}
+
+		count += len(changes)
+	}
+
+	log.Info().Msgf("%d file(s) would change", count)
+
+	return nil
+}
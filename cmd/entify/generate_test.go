@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateFlags(t *testing.T) {
+	for _, cmd := range []*cobra.Command{rootCmd, generateCmd} {
+		assert.NotNil(t, cmd.Flags().Lookup("dry-run"), cmd.Name())
+		assert.NotNil(t, cmd.Flags().Lookup("diff"), cmd.Name())
+	}
+
+	for _, cmd := range []*cobra.Command{checkCmd, inspectCmd} {
+		assert.Nil(t, cmd.InheritedFlags().Lookup("dry-run"), cmd.Name())
+		assert.Nil(t, cmd.InheritedFlags().Lookup("diff"), cmd.Name())
+		assert.Nil(t, cmd.LocalFlags().Lookup("dry-run"), cmd.Name())
+	}
+}
+
+func TestBuilderRunError(t *testing.T) {
+	dir := t.TempDir()
+	specFile := filepath.Join(dir, "schema.hcl")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/acme/app\n"), 0600))
+	assert.NoError(t, os.WriteFile(specFile, []byte(`
+table "logs" {
+  schema = schema.main
+
+  column "message" {
+    null = false
+    type = text
+  }
+}
+
+schema "main" {
+}
+`), 0600))
+
+	defer func(output string, provider string) {
+		outputFlag, providerFlag = output, provider
+	}(outputFlag, providerFlag)
+
+	outputFlag, providerFlag = filepath.Join(dir, "entity"), "sqlite"
+
+	err := builderRun(generateCmd, []string{specFile})
+	assert.ErrorContains(t, err, "table logs has no primary key")
+
+	err = builderRun(generateCmd, []string{filepath.Join(dir, "missing.hcl")})
+	assert.ErrorContains(t, err, "open spec file failed")
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	stdlog "log"
 	"os"
 	"path"
@@ -14,54 +16,76 @@ import (
 )
 
 var (
-	outputFlag   string
-	providerFlag string
+	configFlag          string
+	outputFlag          string
+	packageFlag         string
+	archiveFlag         string
+	providerFlag        string
+	createdAtColumnFlag string
+	updatedAtColumnFlag string
+	deletedAtColumnFlag string
+	versionColumnFlag   string
+	typesFlag           string
+	templatesFlag       string
+	nullableFlag        string
+	columnOrderFlag     string
+	includeFlag         []string
+	excludeFlag         []string
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "entify",
+	Use:   "entify [file|dir|glob]...",
 	Short: "Entify is a entity generator",
+	Args:  cobra.ArbitraryArgs,
 	RunE:  builderRun,
+
+	PersistentPreRunE: loadConfig,
+
+	// the errors of the commands are logged by main.
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 func init() {
 	dest := path.Join(".", "entify", "entity")
 
+	rootCmd.PersistentFlags().StringVarP(&configFlag, "config", "c", "", "config file (default is entify.yaml, entify.yml or entify.hcl in the working directory)")
 	rootCmd.PersistentFlags().StringVarP(&outputFlag, "out", "o", dest, "out directory (default is ./entify/entity)")
-	rootCmd.PersistentFlags().StringVarP(&providerFlag, "provider", "p", "", "out directory (mysql, postgres)")
+	rootCmd.PersistentFlags().StringVar(&archiveFlag, "archive", "", "write the generated files in a .tar, .tar.gz, .tgz or .zip archive instead of the out directory")
+	rootCmd.PersistentFlags().StringVar(&packageFlag, "package", "", "name of the generated package when the spec has a single schema (default is entity)")
+	rootCmd.PersistentFlags().StringVarP(&providerFlag, "provider", "p", "", "schema provider (mysql, mariadb, postgres, sqlite), detected from the spec when empty")
+
+	config := builder.DefaultConfig()
+
+	rootCmd.PersistentFlags().StringVar(&createdAtColumnFlag, "created-at", config.CreatedAtColumn, "column set on creation (empty to disable)")
+	rootCmd.PersistentFlags().StringVar(&updatedAtColumnFlag, "updated-at", config.UpdatedAtColumn, "column set on creation and update (empty to disable)")
+	rootCmd.PersistentFlags().StringVar(&deletedAtColumnFlag, "deleted-at", config.DeletedAtColumn, "nullable column used to soft delete (empty to disable)")
+	rootCmd.PersistentFlags().StringVar(&versionColumnFlag, "version-column", config.VersionColumn, "integer column used for optimistic locking (empty to disable)")
+	rootCmd.PersistentFlags().StringVar(&nullableFlag, "nullable", string(config.NullableMode), "Go representation of nullable columns (value, pointer, sql)")
+	rootCmd.PersistentFlags().StringVar(&columnOrderFlag, "column-order", string(config.ColumnOrder), "order of the entity fields (spec, name), name sorts them after the primary key")
+	rootCmd.PersistentFlags().StringVar(&typesFlag, "types", "", "YAML file of Go type overrides per column or SQL type")
+	rootCmd.PersistentFlags().StringVar(&templatesFlag, "templates", "", "dir of templates overriding the embedded ones by path or rendered as extra files")
+	rootCmd.PersistentFlags().StringSliceVar(&includeFlag, "include", nil, "glob patterns of the tables to generate (default is all)")
+	rootCmd.PersistentFlags().StringSliceVar(&excludeFlag, "exclude", nil, "glob patterns of the tables to skip")
 }
 
 func builderRun(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
-		return cmd.Usage()
+		args = project.Specs
 	}
 
-	filename := args[0]
+	if len(args) == 0 {
+		return cmd.Usage()
+	}
 
 	loader := spec.New()
 
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
-		log.Error().Err(err).Msg("")
-	}
-
-	data, err := loader.ParseFile(providerFlag, filename)
+	realm, err := loader.ParseFiles(stringSetting(cmd, "provider", providerFlag, project.Provider), args...)
 	if err != nil {
-		log.Error().Err(err).Msg("open spec file failed")
-
-		os.Exit(1)
-	}
-
-	dest := outputFlag
-
-	if err := builder.New(data, dest).Build(); err != nil {
-		log.Error().Err(err).Msg("generate entity files failed")
-
-		os.Exit(1)
+		return fmt.Errorf("open spec file failed: %w", err)
 	}
 
-	log.Info().Msg("done")
-
-	return nil
+	return generate(cmd, realm)
 }
 
 func main() {
@@ -78,7 +102,9 @@ func main() {
 	log.Logger = logger
 
 	if err := rootCmd.Execute(); err != nil {
-		log.Error().Err(err).Msg("")
+		if !errors.Is(err, errOutdated) {
+			log.Error().Err(err).Msg("")
+		}
 
 		os.Exit(1)
 	}
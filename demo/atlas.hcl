@@ -130,6 +130,60 @@ table "users_activations" {
   }
 }
 
+table "posts" {
+  schema = schema.demo
+
+  column "id" {
+    null           = false
+    type           = bigint
+    auto_increment = true
+  }
+
+  column "user_id" {
+    null = false
+    type = char(36)
+  }
+
+  column "title" {
+    null = false
+    type = varchar(255)
+  }
+
+  column "status" {
+    null    = false
+    type    = enum("draft", "published", "archived")
+    default = "draft"
+  }
+
+  column "version" {
+    null     = false
+    type     = int
+    unsigned = true
+    default  = 1
+  }
+
+  column "tags" {
+    null = true
+    type = json
+  }
+
+  column "metadata" {
+    null = true
+    type = json
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+
+  foreign_key "posts_ibfk_1" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_update   = "RESTRICT"
+    on_delete   = "RESTRICT"
+  }
+}
+
 schema "demo" {
   charset   = "utf8mb4"
   collation = "utf8mb4_general_ci"
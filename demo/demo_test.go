@@ -3,12 +3,20 @@ package demo
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/euskadi31/entify/entify/entity"
+	"github.com/euskadi31/entify/entify/entity/post"
 	"github.com/stretchr/testify/assert"
 )
 
+var now = time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+func clock() time.Time {
+	return now
+}
+
 func TestUserClientUpdateOne(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	if err != nil {
@@ -16,11 +24,11 @@ func TestUserClientUpdateOne(t *testing.T) {
 	}
 	defer db.Close()
 
-	mock.ExpectExec("UPDATE `users` SET `email` = ? WHERE `id` = ?").
-		WithArgs("user@email.tld", "fdgfgh").
+	mock.ExpectExec("UPDATE `users` SET `email` = ?, `updated_at` = ? WHERE `id` = ?").
+		WithArgs("user@email.tld", now, "fdgfgh").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	c := entity.NewClient("mysql", db)
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
 
 	ctx := context.Background()
 
@@ -44,16 +52,94 @@ func TestUserClientDeleteOne(t *testing.T) {
 	}
 	defer db.Close()
 
+	mock.ExpectExec("UPDATE `users` SET `deleted_at` = ?, `updated_at` = ? WHERE `deleted_at` IS NULL AND `id` = ?").
+		WithArgs(now, now, "ertyht").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	ctx := context.Background()
+
+	err = c.User.DeleteOneID("ertyht").Exec(ctx)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserClientDeleteOneNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE `users` SET `deleted_at` = ?, `updated_at` = ? WHERE `deleted_at` IS NULL AND `id` = ?").
+		WithArgs(now, now, "ertyht").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	err = c.User.DeleteOneID("ertyht").Exec(context.Background())
+
+	var notFound *entity.NotFoundError
+
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "users", notFound.Table)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserClientHardDeleteOne(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
 	mock.ExpectExec("DELETE FROM `users` WHERE `id` = ?").
 		WithArgs("ertyht").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	c := entity.NewClient("mysql", db)
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
 
 	ctx := context.Background()
 
-	err = c.User.DeleteOneID("ertyht").Exec(ctx)
+	err = c.User.HardDeleteOneID("ertyht").Exec(ctx)
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserClientQueryIDs(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT `users`.`id` FROM `users` WHERE `users`.`deleted_at` IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("fdgdgdgh"))
+
+	mock.ExpectQuery("SELECT `users`.`id` FROM `users`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("fdgdgdgh").AddRow("yrtyrtgr"))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	ctx := context.Background()
+
+	ids, err := c.User.Query().IDs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fdgdgdgh"}, ids)
+
+	ids, err = c.User.Query().WithDeleted().IDs(ctx)
 	assert.NoError(t, err)
+	assert.Equal(t, []string{"fdgdgdgh", "yrtyrtgr"}, ids)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -71,7 +157,7 @@ func TestUserClientQueryFindOne(t *testing.T) {
 		WithArgs("fdgdgdgh").
 		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password"}).AddRow("fdgdgdgh", "user@email.tld", "passw0rd"))
 
-	c := entity.NewClient("mysql", db)
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
 
 	ctx := context.Background()
 
@@ -103,7 +189,7 @@ func TestUserClientQueryFindAll(t *testing.T) {
 				AddRow("yrtyrtgr", "user2@email.tld", "p1ssw0rd"),
 		)
 
-	c := entity.NewClient("mysql", db)
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
 
 	ctx := context.Background()
 
@@ -133,9 +219,11 @@ func TestUserCreate(t *testing.T) {
 	}
 	defer db.Close()
 
-	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `password`) VALUES (?, ?, ?)").WithArgs("fdgfgh", "user@email.tld", "fdghfghgfh").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `password`, `enabled`, `expired`, `locked`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("fdgfgh", "user@email.tld", "fdghfghgfh", false, false, false, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	c := entity.NewClient("mysql", db)
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
 
 	ctx := context.Background()
 
@@ -156,6 +244,41 @@ func TestUserCreate(t *testing.T) {
 	}
 }
 
+func TestUserCreateWithDefaults(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `password`, `enabled`, `expired`, `locked`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("fdgfgh", "user@email.tld", "fdghfghgfh", true, false, false, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	ctx := context.Background()
+
+	u, err := c.User.Create().
+		SetID("fdgfgh").
+		SetEmail("user@email.tld").
+		SetPassword("fdghfghgfh").
+		SetEnabled(true).
+		Save(ctx)
+
+	assert.NoError(t, err)
+
+	assert.True(t, u.GetEnabled())
+	assert.False(t, u.GetExpired())
+	assert.False(t, u.GetLocked())
+	assert.Equal(t, now, u.GetCreatedAt())
+	assert.Equal(t, now, u.GetUpdatedAt())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestUserUpdateOne(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	if err != nil {
@@ -163,15 +286,15 @@ func TestUserUpdateOne(t *testing.T) {
 	}
 	defer db.Close()
 
-	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `password`) VALUES (?, ?, ?)").
-		WithArgs("fdgfgh", "user@email.tld", "fdghfghgfh").
+	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `password`, `enabled`, `expired`, `locked`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("fdgfgh", "user@email.tld", "fdghfghgfh", false, false, false, now, now).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	mock.ExpectExec("UPDATE `users` SET `email` = ? WHERE `id` = ?").
-		WithArgs("user+test2@email.tld", "fdgfgh").
+	mock.ExpectExec("UPDATE `users` SET `email` = ?, `updated_at` = ? WHERE `id` = ?").
+		WithArgs("user+test2@email.tld", now, "fdgfgh").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	c := entity.NewClient("mysql", db)
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
 
 	ctx := context.Background()
 
@@ -207,15 +330,15 @@ func TestUserUpdateOneWithNullableEmail(t *testing.T) {
 	}
 	defer db.Close()
 
-	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `firstname`, `password`) VALUES (?, ?, ?, ?)").
-		WithArgs("fdgfgh", "user@email.tld", "foo", "fdghfghgfh").
+	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `firstname`, `password`, `enabled`, `expired`, `locked`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("fdgfgh", "user@email.tld", "foo", "fdghfghgfh", false, false, false, now, now).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	mock.ExpectExec("UPDATE `users` SET `firstname` = ? WHERE `id` = ?").
-		WithArgs(nil, "fdgfgh").
+	mock.ExpectExec("UPDATE `users` SET `firstname` = ?, `updated_at` = ? WHERE `id` = ?").
+		WithArgs(nil, now, "fdgfgh").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	c := entity.NewClient("mysql", db)
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
 
 	ctx := context.Background()
 
@@ -254,15 +377,15 @@ func TestUserDeleteOne(t *testing.T) {
 	}
 	defer db.Close()
 
-	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `password`) VALUES (?, ?, ?)").
-		WithArgs("fdgfgh", "user@email.tld", "fdghfghgfh").
+	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `password`, `enabled`, `expired`, `locked`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("fdgfgh", "user@email.tld", "fdghfghgfh", false, false, false, now, now).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	mock.ExpectExec("DELETE FROM `users` WHERE `id` = ?").
-		WithArgs("fdgfgh").
+	mock.ExpectExec("UPDATE `users` SET `deleted_at` = ?, `updated_at` = ? WHERE `deleted_at` IS NULL AND `id` = ?").
+		WithArgs(now, now, "fdgfgh").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	c := entity.NewClient("mysql", db)
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
 
 	ctx := context.Background()
 
@@ -285,3 +408,272 @@ func TestUserDeleteOne(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestPostUpdateOneWithVersion(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO `posts` (`user_id`, `title`, `status`, `version`) VALUES (?, ?, ?, ?)").
+		WithArgs("fdgfgh", "Hello", "draft", 1).
+		WillReturnResult(sqlmock.NewResult(42, 1))
+
+	mock.ExpectExec("UPDATE `posts` SET `title` = ?, `version` = ? WHERE `version` = ? AND `id` = ?").
+		WithArgs("Hello World", 2, 1, 42).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec("UPDATE `posts` SET `title` = ?, `version` = ? WHERE `version` = ? AND `id` = ?").
+		WithArgs("Hello", 3, 2, 42).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	ctx := context.Background()
+
+	p, err := c.Post.Create().
+		SetUserID("fdgfgh").
+		SetTitle("Hello").
+		Save(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(42), p.GetID())
+	assert.Equal(t, post.StatusDraft, p.GetStatus())
+	assert.Equal(t, uint32(1), p.GetVersion())
+
+	p, err = p.Update().SetTitle("Hello World").Save(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint32(2), p.GetVersion())
+
+	_, err = p.Update().SetTitle("Hello").Save(ctx)
+
+	var stale *entity.StaleObjectError
+
+	assert.ErrorAs(t, err, &stale)
+	assert.Equal(t, "posts", stale.Table)
+	assert.Equal(t, int64(2), stale.Version)
+
+	assert.Equal(t, "Hello World", p.GetTitle())
+	assert.Equal(t, uint32(2), p.GetVersion())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPostUpdateOneSetVersion(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE `posts` SET `title` = ?, `version` = ? WHERE `id` = ?").
+		WithArgs("Hello", 10, 42).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec("UPDATE `posts` SET `title` = ?, `version` = COALESCE(`version`, 0) + ? WHERE `id` = ?").
+		WithArgs("Hello World", 1, 42).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	p, err := c.Post.UpdateOneID(42).
+		SetTitle("Hello").
+		SetVersion(10).
+		Save(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint32(10), p.GetVersion())
+
+	// the bumped version is not read back without the lock.
+	p, err = c.Post.UpdateOneID(42).
+		SetTitle("Hello World").
+		Save(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint32(0), p.GetVersion())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPostEnumStatus(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO `posts` (`user_id`, `title`, `status`, `version`) VALUES (?, ?, ?, ?)").
+		WithArgs("fdgfgh", "Hello", "published", 1).
+		WillReturnResult(sqlmock.NewResult(42, 1))
+
+	mock.ExpectQuery("SELECT * FROM posts WHERE id = ?").
+		WithArgs(42).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(42, "archived"))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	ctx := context.Background()
+
+	p, err := c.Post.Create().
+		SetUserID("fdgfgh").
+		SetTitle("Hello").
+		SetStatus(post.StatusPublished).
+		Save(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, post.StatusPublished, p.GetStatus())
+
+	_, err = c.Post.Create().
+		SetUserID("fdgfgh").
+		SetTitle("Hello").
+		SetStatus(post.StatusValue("deleted")).
+		Save(ctx)
+	assert.Error(t, err)
+
+	p, err = c.Post.Query().FindOne(ctx, "SELECT * FROM posts WHERE id = ?", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, post.StatusArchived, p.GetStatus())
+
+	assert.True(t, post.StatusDraft.IsValid())
+	assert.False(t, post.StatusValue("deleted").IsValid())
+	assert.Equal(t, []post.StatusValue{post.StatusDraft, post.StatusPublished, post.StatusArchived}, post.StatusValue("").Values())
+
+	var status post.StatusValue
+
+	assert.NoError(t, status.Scan("draft"))
+	assert.Equal(t, post.StatusDraft, status)
+	assert.NoError(t, status.Scan([]byte("archived")))
+	assert.Equal(t, post.StatusArchived, status)
+	assert.EqualError(t, status.Scan("deleted"), `invalid value "deleted" for status enum`)
+	assert.EqualError(t, status.Scan(nil), "unexpected type <nil> for status enum")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPostTypedJSON(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO `posts` (`user_id`, `title`, `status`, `version`, `tags`) VALUES (?, ?, ?, ?, ?)").
+		WithArgs("fdgfgh", "Hello", "draft", 1, `["go","sql"]`).
+		WillReturnResult(sqlmock.NewResult(42, 1))
+
+	mock.ExpectQuery("SELECT * FROM posts WHERE id = ?").
+		WithArgs(42).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tags", "metadata"}).AddRow(42, `["go"]`, `{"lang":"en"}`))
+
+	mock.ExpectQuery("SELECT * FROM posts WHERE id = ?").
+		WithArgs(43).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tags"}).AddRow(43, `{"go"}`))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	ctx := context.Background()
+
+	p, err := c.Post.Create().
+		SetUserID("fdgfgh").
+		SetTitle("Hello").
+		SetTags([]string{"go", "sql"}).
+		Save(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "sql"}, p.GetTags())
+
+	p, err = c.Post.Query().FindOne(ctx, "SELECT * FROM posts WHERE id = ?", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go"}, p.GetTags())
+	assert.JSONEq(t, `{"lang":"en"}`, string(p.GetMetadata()))
+
+	_, err = c.Post.Query().FindOne(ctx, "SELECT * FROM posts WHERE id = ?", 43)
+	assert.Error(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserCreateNillable(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO `users` (`id`, `email`, `firstname`, `password`, `enabled`, `expired`, `locked`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)").
+		WithArgs("fdgfgh", "user@email.tld", "John", "fdghfghgfh", false, false, false, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	ctx := context.Background()
+
+	firstname := "John"
+
+	u, err := c.User.Create().
+		SetID("fdgfgh").
+		SetEmail("user@email.tld").
+		SetFirstnameNillable(&firstname).
+		SetLastnameNillable(nil).
+		SetPassword("fdghfghgfh").
+		Save(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "John", u.GetFirstname())
+	assert.Equal(t, "", u.GetLastname())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUserActivationUnsignedStatus(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO `users_activations` (`user_id`, `code`, `status`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?)").
+		WithArgs("fdgfgh", "123456789012345", uint8(255), now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectQuery("SELECT * FROM users_activations WHERE code = ?").
+		WithArgs("123456789012345").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "code", "status"}).AddRow("fdgfgh", "123456789012345", 255))
+
+	mock.ExpectQuery("SELECT * FROM posts WHERE id = ?").
+		WithArgs(42).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(42, uint64(4294967295)))
+
+	c := entity.NewClient("mysql", db, entity.WithClock(clock))
+
+	ctx := context.Background()
+
+	ua, err := c.UserActivation.Create().
+		SetUserID("fdgfgh").
+		SetCode("123456789012345").
+		SetStatus(255).
+		Save(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), ua.GetStatus())
+
+	ua, err = c.UserActivation.Query().FindOne(ctx, "SELECT * FROM users_activations WHERE code = ?", "123456789012345")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), ua.GetStatus())
+
+	p, err := c.Post.Query().FindOne(ctx, "SELECT * FROM posts WHERE id = ?", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(4294967295), p.GetVersion())
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,47 @@
+table "users" {
+  schema = schema.main
+
+  column "id" {
+    null           = false
+    type           = integer
+    auto_increment = true
+  }
+
+  column "email" {
+    null = false
+    type = varchar(90)
+  }
+
+  column "firstname" {
+    null = true
+    type = varchar(45)
+  }
+
+  column "created_at" {
+    null = false
+    type = datetime
+  }
+
+  column "updated_at" {
+    null = true
+    type = datetime
+  }
+
+  column "deleted_at" {
+    null = true
+    type = datetime
+  }
+
+  column "version" {
+    null    = false
+    type    = integer
+    default = 1
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+}
+
+schema "main" {
+}
@@ -0,0 +1,93 @@
+package demo
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"github.com/euskadi31/entify/entify/sqlite"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+const sqliteSchema = `CREATE TABLE users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	email VARCHAR(90) NOT NULL,
+	firstname VARCHAR(45) NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NULL,
+	deleted_at DATETIME NULL,
+	version INTEGER NOT NULL DEFAULT 1
+)`
+
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "demo.db"))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening the sqlite database", err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the sqlite schema", err)
+	}
+
+	return db
+}
+
+func TestSQLiteUserCRUD(t *testing.T) {
+	db := openSQLite(t)
+
+	c := sqlite.NewClient(dialect.SQLite, db, sqlite.WithClock(clock))
+
+	ctx := context.Background()
+
+	u, err := c.User.Create().
+		SetEmail("user@email.tld").
+		SetFirstname("John").
+		Save(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), u.GetID())
+	assert.Equal(t, int32(1), u.GetVersion())
+
+	u, err = c.User.Query().FindOne(ctx, "SELECT * FROM users WHERE id = ?", u.GetID())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "user@email.tld", u.GetEmail())
+	assert.Equal(t, "John", u.GetFirstname())
+	assert.True(t, now.Equal(u.GetCreatedAt()))
+
+	u, err = u.Update().SetEmail("john@email.tld").Save(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(2), u.GetVersion())
+
+	u, err = c.User.Query().FindOne(ctx, "SELECT * FROM users WHERE id = ?", u.GetID())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "john@email.tld", u.GetEmail())
+	assert.Equal(t, int32(2), u.GetVersion())
+
+	assert.NoError(t, u.Delete().Exec(ctx))
+
+	var notFound *sqlite.NotFoundError
+
+	assert.ErrorAs(t, c.User.DeleteOneID(u.GetID()).Exec(ctx), &notFound)
+
+	users, err := c.User.Query().FindAll(ctx, "SELECT * FROM users WHERE deleted_at IS NULL")
+	assert.NoError(t, err)
+	assert.Len(t, users, 0)
+
+	assert.NoError(t, c.User.HardDeleteOneID(u.GetID()).Exec(ctx))
+
+	users, err = c.User.Query().FindAll(ctx, "SELECT * FROM users")
+	assert.NoError(t, err)
+	assert.Len(t, users, 0)
+}
@@ -4,17 +4,20 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
-	"os"
+	"io/fs"
 	"path"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"ariga.io/atlas/sql/schema"
 	tmpl "github.com/euskadi31/entify/pkg/template"
 	"github.com/euskadi31/entify/pkg/types"
 	"github.com/gertd/go-pluralize"
 	"github.com/rs/zerolog/log"
-	"golang.org/x/mod/modfile"
 )
 
 var pattern = regexp.MustCompile(`__([a-z0-9\-]+)__`)
@@ -44,6 +47,10 @@ func init() {
 		"mediumint": "int32",
 		"int":       "int32",
 		"bigint":    "int64",
+		"int2":      "int16",
+		"int4":      "int32",
+		"integer":   "int32",
+		"int8":      "int64",
 	}
 
 	typeUintMap = map[string]string{
@@ -52,6 +59,7 @@ func init() {
 		"mediumint": "uint32",
 		"int":       "uint32",
 		"bigint":    "uint64",
+		"oid":       "uint32",
 	}
 
 	typeNullableIntMap = map[string]string{
@@ -60,68 +68,122 @@ func init() {
 		"mediumint": "sql.NullInt32",
 		"int":       "sql.NullInt32",
 		"bigint":    "sql.NullInt64",
+		"int2":      "sql.NullInt16",
+		"int4":      "sql.NullInt32",
+		"integer":   "sql.NullInt32",
+		"int8":      "sql.NullInt64",
 	}
 }
 
 type Builder struct {
-	dest string
-	spec schema.Schema
-	data *types.Data
-	tpl  *tmpl.Engine
+	dir        string
+	fs         FS
+	modulePath string
+	config     Config
+	spec       schema.Schema
+	data       *types.Data
+	tpl        *tmpl.Engine
+	templates  []fs.FS
+	workers    int
+	extensions []Extension
+	names      initialisms
+	written    map[string]struct{}
+	stats      Stats
 }
 
-func New(spec schema.Schema, dest string) *Builder {
-	return &Builder{
-		dest: dest,
-		spec: spec,
+// Stats reports the outcome of a Build.
+type Stats struct {
+	// Written is the number of files written.
+	Written int
+
+	// Unchanged is the number of files not written since their content is unchanged.
+	Unchanged int
+
+	// Removed is the number of stale files removed.
+	Removed int
+
+	// Duration is the duration of the Build.
+	Duration time.Duration
+}
+
+// New returns a builder of the entities of the schema,
+// the generated files are written with the WithOutput or the WithFS option.
+func New(spec schema.Schema, opts ...Option) *Builder {
+	b := &Builder{
+		config: DefaultConfig(),
+		spec:   spec,
 		data: &types.Data{
 			Package: "entity",
 		},
-		tpl: tmpl.Must(tmpl.New()),
 	}
-}
 
-func (b *Builder) processSpec() {
-	dir, err := os.Getwd()
-	if err != nil {
-		panic(err)
+	for _, opt := range opts {
+		opt(b)
 	}
 
-	moddir := findModuleRoot(dir)
-
-	mod, err := readModFile(moddir)
-	if err != nil {
-		panic(err)
-	}
+	b.names = newInitialisms(b.config.Initialisms...)
 
-	module := modfile.ModulePath(mod)
+	return b
+}
 
-	wd, err := os.Getwd()
-	if err != nil {
-		log.Error().Err(err).Msg("get working dir failed")
+func (b *Builder) processSpec() error {
+	module := b.modulePath
 
-		return
+	if err := b.config.Validate(); err != nil {
+		return err
 	}
 
-	m := findCurrentModulePath(wd)
+	for _, t := range sortedTables(b.spec.Tables) {
+		if !b.config.TableEnabled(t.Name) {
+			log.Debug().Msgf("skip %s table", t.Name)
 
-	module = path.Join(module, m, b.dest)
+			continue
+		}
+
+		// the entities are updated and deleted by primary key.
+		if t.PrimaryKey == nil || len(t.PrimaryKey.Parts) == 0 {
+			return fmt.Errorf("table %s has no primary key, exclude it from the generation", t.Name)
+		}
 
-	for _, t := range b.spec.Tables {
 		importsMap := map[string]struct{}{}
 		imports := []string{}
 
+		sqlImportsMap := map[string]struct{}{}
+		sqlImports := []string{}
+
 		pks := []*types.Field{}
 		pksMap := map[string]struct{}{}
 
+		pkImportsMap := map[string]struct{}{}
+		pkImports := []string{}
+
 		for _, pk := range t.PrimaryKey.Parts {
 			pksMap[pk.C.Name] = struct{}{}
 		}
 
 		fields := []*types.Field{}
+		defaults := []*types.Field{}
+
+		var createdAt, updatedAt, deletedAt, version *types.Field
 
-		for _, col := range t.Columns {
-			ct := ColumnTypeToType(col.Type)
+		enums := []*types.Field{}
+		jsonFields := []*types.Field{}
+		typedJSON := false
+
+		packageImportsMap := map[string]struct{}{}
+		packageImports := []string{}
+
+		for _, col := range b.orderedColumns(t) {
+			ct, err := ColumnTypeToType(col.Type)
+			if err != nil {
+				return fmt.Errorf("table %s column %s: %w", t.Name, col.Name, err)
+			}
+
+			if override := b.config.TypeOverrides.Lookup(t.Name, col); override != nil {
+				if err := override.Apply(ct); err != nil {
+					return fmt.Errorf("table %s column %s: %w", t.Name, col.Name, err)
+				}
+			}
 
 			if ct.Package != "" {
 				if _, ok := importsMap[ct.Package]; !ok {
@@ -130,26 +192,136 @@ func (b *Builder) processSpec() {
 				}
 			}
 
+			if ct.SQLPackage != "" {
+				if _, ok := sqlImportsMap[ct.SQLPackage]; !ok {
+					sqlImports = append(sqlImports, ct.SQLPackage)
+					sqlImportsMap[ct.SQLPackage] = struct{}{}
+				}
+			}
+
 			field := &types.Field{
-				PropertyName:           ColumnNameToPropertyName(col.Name),
-				VariableName:           ColumnNameToVariableName(col.Name),
+				PropertyName:           b.names.propertyName(col.Name),
+				VariableName:           b.names.columnVariableName(col.Name),
 				Name:                   col.Name,
 				Type:                   ct.Type,
+				LocalType:              ct.Type,
 				SQLType:                ct.NullableSQLType,
 				Nullable:               col.Type.Null,
 				TypeKind:               ct.TypeKind,
 				NullableSQLAccessValue: ct.NullableSQLAccessValue,
 				DefaultValue:           ct.DefaultValue,
+				SQLValue:               ct.SQLValue,
+				JSON:                   ct.JSON,
+			}
+
+			if field.TypeKind == types.FieldTypeKindJson {
+				jsonFields = append(jsonFields, field)
+
+				typedJSON = typedJSON || field.JSON
+			}
+
+			if len(ct.EnumValues) > 0 {
+				field.LocalType = field.PropertyName + "Value"
+				field.Type = TableNameToPackageName(t.Name) + "." + field.LocalType
+
+				for _, v := range ct.EnumValues {
+					field.EnumValues = append(field.EnumValues, &types.EnumValue{
+						Name:  EnumValueToConstName(field.PropertyName, v),
+						Value: v,
+					})
+				}
+
+				for _, pkg := range []string{"database/sql/driver", "fmt"} {
+					if _, ok := packageImportsMap[pkg]; !ok {
+						packageImports = append(packageImports, pkg)
+						packageImportsMap[pkg] = struct{}{}
+					}
+				}
+
+				enums = append(enums, field)
+			}
+
+			field.StructType = field.Type
+
+			if field.Nullable {
+				switch b.config.NullableMode {
+				case NullableModePointer:
+					field.Pointer = true
+					field.StructType = "*" + field.Type
+				case NullableModeSQLNull:
+					field.SQLNull = true
+					field.StructType = "sql.Null[" + field.Type + "]"
+				}
+			}
+
+			scanValue := ct.ScanValue
+			if scanValue == "" {
+				scanValue = field.Type + "(%s)"
+			}
+
+			field.ScanValue = fmt.Sprintf(scanValue, "value."+ct.NullableSQLAccessValue)
+
+			if d := ColumnDefaultToValue(col, ct, field.PropertyName); d != nil {
+				field.Default = d.Value
+				field.DefaultFunc = d.Func
+
+				if d.Package != "" {
+					if _, ok := packageImportsMap[d.Package]; !ok {
+						packageImports = append(packageImports, d.Package)
+						packageImportsMap[d.Package] = struct{}{}
+					}
+				}
+
+				defaults = append(defaults, field)
+			}
+
+			if field.TypeKind == types.FieldTypeKindDate {
+				switch col.Name {
+				case b.config.CreatedAtColumn:
+					createdAt = field
+				case b.config.UpdatedAtColumn:
+					updatedAt = field
+				case b.config.DeletedAtColumn:
+					if field.Nullable {
+						deletedAt = field
+					}
+				}
+			}
+
+			if field.TypeKind == types.FieldTypeKindNumber && col.Name == b.config.VersionColumn {
+				version = field
 			}
 
 			// set field to primary keys
 			if _, ok := pksMap[col.Name]; ok {
 				pks = append(pks, field)
+
+				if _, ok := pkImportsMap[ct.Package]; ct.Package != "" && !ok {
+					pkImports = append(pkImports, ct.Package)
+					pkImportsMap[ct.Package] = struct{}{}
+				}
 			}
 
 			fields = append(fields, field)
 		}
 
+		jsonImports := []string{}
+
+		// the typed JSON fields are unmarshaled with encoding/json, unless already imported by a field type.
+		if _, ok := importsMap["encoding/json"]; typedJSON && !ok {
+			jsonImports = append(jsonImports, "encoding/json")
+		}
+
+		clientImports := []string{pkgDatabaseSQL, "time"}
+
+		for _, pkg := range pkImports {
+			if pkg != pkgDatabaseSQL && pkg != "time" {
+				clientImports = append(clientImports, pkg)
+			}
+		}
+
+		sortImports(imports, sqlImports, packageImports, pkImports, clientImports)
+
 		autoIncr := false
 
 		if len(pks) == 1 && pks[0].TypeKind == types.FieldTypeKindNumber {
@@ -161,23 +333,38 @@ func (b *Builder) processSpec() {
 			ReceiverVarName:    TableNameToReceiver(t.Name),
 			Module:             module,
 			Name:               t.Name,
-			VariableName:       TableNameToVariableName(t.Name),
+			VariableName:       b.names.tableVariableName(t.Name),
 			Filename:           TableNameToFileName(t.Name),
 			StructName:         TableNameToStructName(t.Name),
 			PackageName:        TableNameToPackageName(t.Name),
 			Imports:            imports,
+			SQLImports:         sqlImports,
+			PackageImports:     packageImports,
 			Fields:             fields,
+			Defaults:           defaults,
+			Enums:              enums,
+			JSONFields:         jsonFields,
+			JSONImports:        jsonImports,
+			TypedJSON:          typedJSON,
+			CreatedAt:          createdAt,
+			UpdatedAt:          updatedAt,
+			DeletedAt:          deletedAt,
+			Version:            version,
 			FieldsCount:        len(fields),
 			PrimaryKeys:        pks,
+			PrimaryKeyImports:  pkImports,
+			ClientImports:      clientImports,
 			PrimaryKeyAutoIncr: autoIncr,
 		})
 	}
+
+	return nil
 }
 
 // name = predicate/predicate.go.tmpl => predicate/predicate.go, placeholder = ""
 // name = __entity__/__entity__.go => user/user.go, placeholder = "user".
 func (b *Builder) getDestFilename(name string, placeholders map[string]string) string {
-	filename := strings.Replace(name, ".go.tmpl", ".go", 1)
+	filename := strings.TrimSuffix(strings.Replace(name, ".go.tmpl", ".go", 1), ".tmpl")
 
 	if len(placeholders) > 0 {
 		matches := pattern.FindAllStringSubmatch(name, 10)
@@ -187,69 +374,213 @@ func (b *Builder) getDestFilename(name string, placeholders map[string]string) s
 		}
 	}
 
-	filename = path.Join(strings.Split(filename, "/")...)
+	return path.Join(strings.Split(filename, "/")...)
+}
 
-	return path.Join(b.dest, filename)
+// renderJob is a template rendered by the workers of Build.
+type renderJob struct {
+	name         string
+	placeholders map[string]string
+	data         interface{}
+	dest         string
+	content      []byte
 }
 
-func (b *Builder) render(name string, placeholders map[string]string, data interface{}) error {
-	if data == nil {
-		data = b.data
+// render executes the template of the job and formats its content.
+func (b *Builder) render(job *renderJob) error {
+	job.dest = b.getDestFilename(job.name, job.placeholders)
+
+	buf := bytes.NewBuffer(nil)
+
+	if err := b.tpl.ExecuteTemplate(buf, job.name, job.data); err != nil {
+		return fmt.Errorf("exec %s template: %w", job.name, err)
 	}
 
-	dest := b.getDestFilename(name, placeholders)
+	job.content = buf.Bytes()
 
-	log.Debug().Msgf("create %s", dest)
+	// only the Go sources are formatted, an extra template may render any file.
+	if strings.HasSuffix(job.dest, ".go") {
+		content, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("format %s source failed: %w", job.name, err)
+		}
 
-	f, err := os.Create(dest)
-	if err != nil {
-		return fmt.Errorf("create %s file failed: %w", dest, err)
+		// start dev mode
+		// content := buf.Bytes()
+		// end dev mode
+
+		job.content = content
 	}
 
-	defer f.Close()
+	for _, ext := range b.extensions {
+		content, err := ext.File(job.dest, job.content)
+		if err != nil {
+			return fmt.Errorf("extension %T file %s: %w", ext, job.dest, err)
+		}
 
-	buf := bytes.NewBuffer(nil)
+		job.content = content
+	}
+
+	return nil
+}
 
-	if err := b.tpl.ExecuteTemplate(buf, name, data); err != nil {
-		return fmt.Errorf("exec template: %w", err)
+// renderAll renders the jobs with a bounded pool of workers, the first error is returned.
+func (b *Builder) renderAll(jobs []*renderJob) error {
+	workers := b.workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
 	}
 
-	content, err := format.Source(buf.Bytes())
-	if err != nil {
-		return fmt.Errorf("format %s source failed: %w", name, err)
+	queue := make(chan *renderJob)
+	errs := make(chan error, len(jobs))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for job := range queue {
+				if err := b.render(job); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		queue <- job
+	}
+
+	close(queue)
+
+	wg.Wait()
+
+	close(errs)
+
+	return <-errs
+}
+
+// write writes the rendered content of the job, unless the file has the same content.
+func (b *Builder) write(job *renderJob) error {
+	b.written[job.dest] = struct{}{}
+
+	if fsys, ok := b.fs.(ReadFileFS); ok {
+		if content, err := fsys.ReadFile(job.dest); err == nil && bytes.Equal(content, job.content) {
+			log.Debug().Msgf("unchanged %s", path.Join(b.dir, job.dest))
+
+			b.stats.Unchanged++
+
+			return nil
+		}
 	}
 
-	// start dev mode
-	// content := buf.Bytes()
-	// end dev mode
+	log.Debug().Msgf("create %s", path.Join(b.dir, job.dest))
 
-	if _, err := f.Write(content); err != nil {
-		return fmt.Errorf("write %s file failed: %w", dest, err)
+	if err := b.fs.WriteFile(job.dest, job.content, 0644); err != nil {
+		return fmt.Errorf("write %s file failed: %w", job.dest, err)
 	}
 
+	b.stats.Written++
+
 	return nil
 }
 
-func (b *Builder) generatePredicate() error {
-	return b.render("predicate/predicate.go.tmpl", nil, nil)
-}
+// writeManifest lists the generated files without GeneratedHeader in the ManifestFile,
+// e.g. the GraphQL schema of an extra template, so they are removed once stale and checked.
+func (b *Builder) writeManifest(jobs []*renderJob) error {
+	names := []string{}
+
+	for _, job := range jobs {
+		if !bytes.HasPrefix(job.content, []byte(GeneratedHeader)) {
+			names = append(names, job.dest)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	sort.Strings(names)
+
+	buf := bytes.NewBufferString(manifestHeader + "\n")
 
-func (b *Builder) generateClient() error {
-	return b.render("client.go.tmpl", nil, nil)
+	for _, name := range names {
+		buf.WriteString(name + "\n")
+	}
+
+	return b.write(&renderJob{
+		dest:    ManifestFile,
+		content: buf.Bytes(),
+	})
 }
 
-func (b *Builder) generateEntity(entity *types.Entity) error {
-	if err := b.render("__entity-package__/__entity-file__.go.tmpl", map[string]string{
-		"entity-package": entity.PackageName,
-		"entity-file":    entity.Filename,
-	}, entity); err != nil {
-		return fmt.Errorf("generate entity file: %w", err)
+// jobs returns the templates to render: the client, the predicates, the files of the entities
+// and the extra templates, rendered per entity when their path has an entity placeholder, once otherwise.
+func (b *Builder) jobs() []*renderJob {
+	jobs := []*renderJob{
+		{
+			name: "client.go.tmpl",
+			data: b.data,
+		},
+		{
+			name: "predicate/predicate.go.tmpl",
+			data: b.data,
+		},
+		{
+			name: "internal/internal.go.tmpl",
+			data: b.data,
+		},
+	}
+
+	extras := []string{}
+
+	for _, name := range b.tpl.Extras() {
+		if isEntityTemplate(name) {
+			extras = append(extras, name)
+
+			continue
+		}
+
+		jobs = append(jobs, &renderJob{
+			name: name,
+			data: b.data,
+		})
+	}
+
+	for _, entity := range b.data.Entities {
+		jobs = append(jobs, b.entityJobs(entity, extras)...)
 	}
 
-	if err := b.render("__entity-package__/where.go.tmpl", map[string]string{
-		"entity-package": entity.PackageName,
-	}, entity); err != nil {
-		return fmt.Errorf("generate where file: %w", err)
+	return jobs
+}
+
+// isEntityTemplate reports whether the template is rendered per entity.
+func isEntityTemplate(name string) bool {
+	return strings.Contains(name, "__entity-file__") || strings.Contains(name, "__entity-package__")
+}
+
+// entityJobs returns the templates of the entity, the templates of the entity package
+// are rendered with the entity, the others with the entity and the package.
+func (b *Builder) entityJobs(entity *types.Entity, extras []string) []*renderJob {
+	jobs := []*renderJob{
+		{
+			name: "__entity-package__/__entity-file__.go.tmpl",
+			placeholders: map[string]string{
+				"entity-package": entity.PackageName,
+				"entity-file":    entity.Filename,
+			},
+			data: entity,
+		},
+		{
+			name: "__entity-package__/where.go.tmpl",
+			placeholders: map[string]string{
+				"entity-package": entity.PackageName,
+			},
+			data: entity,
+		},
 	}
 
 	de := &types.DataEntity{
@@ -267,64 +598,186 @@ func (b *Builder) generateEntity(entity *types.Entity) error {
 		"__entity-file___update.go.tmpl",
 		"__entity-file__.go.tmpl",
 	} {
-		if err := b.render(f, map[string]string{
-			"entity-file": entity.Filename,
-		}, de); err != nil {
-			return fmt.Errorf("generate entity file: %w", err)
+		jobs = append(jobs, &renderJob{
+			name: f,
+			placeholders: map[string]string{
+				"entity-file": entity.Filename,
+			},
+			data: de,
+		})
+	}
+
+	for _, name := range extras {
+		job := &renderJob{
+			name: name,
+			placeholders: map[string]string{
+				"entity-package": entity.PackageName,
+				"entity-file":    entity.Filename,
+			},
+			data: de,
 		}
+
+		if strings.HasPrefix(name, "__entity-package__/") {
+			job.data = entity
+		}
+
+		jobs = append(jobs, job)
 	}
 
-	return nil
+	return jobs
 }
 
 func (b *Builder) createFolders() error {
-	log.Debug().Msgf("create destination dir: %s", b.dest)
+	log.Debug().Msgf("create destination dir: %s", b.dir)
 
-	if err := os.MkdirAll(b.dest, 0755); err != nil {
+	if err := b.fs.MkdirAll(".", 0755); err != nil {
 		return fmt.Errorf("create destination dir failed: %w", err)
 	}
 
 	for _, e := range b.data.Entities {
-		dest := path.Join(b.dest, e.PackageName)
-
-		log.Debug().Msgf("create entity dir: %s", dest)
+		log.Debug().Msgf("create entity dir: %s", path.Join(b.dir, e.PackageName))
 
-		if err := os.MkdirAll(dest, 0755); err != nil {
+		if err := b.fs.MkdirAll(e.PackageName, 0755); err != nil {
 			return fmt.Errorf("create %s dir failed: %w", e.PackageName, err)
 		}
 	}
 
-	dest := path.Join(b.dest, "predicate")
-
-	log.Debug().Msgf("create predicate dir: %s", dest)
+	log.Debug().Msgf("create predicate dir: %s", path.Join(b.dir, "predicate"))
 
-	if err := os.MkdirAll(dest, 0755); err != nil {
+	if err := b.fs.MkdirAll("predicate", 0755); err != nil {
 		return fmt.Errorf("create predicate dir failed: %w", err)
 	}
 
+	log.Debug().Msgf("create internal dir: %s", path.Join(b.dir, "internal"))
+
+	if err := b.fs.MkdirAll("internal", 0755); err != nil {
+		return fmt.Errorf("create internal dir failed: %w", err)
+	}
+
+	return nil
+}
+
+// removeStale removes the generated files of a previous build no longer generated,
+// e.g. the files of a dropped table, the files without the generated header are never removed.
+func (b *Builder) removeStale() error {
+	fsys, ok := b.fs.(CleanFS)
+	if !ok {
+		return nil
+	}
+
+	names, err := fsys.GeneratedFiles()
+	if err != nil {
+		return fmt.Errorf("list generated files failed: %w", err)
+	}
+
+	for _, name := range names {
+		if _, ok := b.written[name]; ok {
+			continue
+		}
+
+		log.Debug().Msgf("remove stale %s", path.Join(b.dir, name))
+
+		if err := fsys.Remove(name); err != nil {
+			return fmt.Errorf("remove %s file failed: %w", name, err)
+		}
+
+		b.stats.Removed++
+	}
+
+	return nil
+}
+
+// prepare checks the output and resolves the defaults depending on the environment.
+func (b *Builder) prepare() error {
+	if b.fs == nil {
+		return fmt.Errorf("output is required, set it with WithOutput or WithFS")
+	}
+
+	if b.modulePath == "" {
+		if b.dir == "" {
+			return fmt.Errorf("module path is required, set it with WithModulePath")
+		}
+
+		module, err := ModulePath(b.dir)
+		if err != nil {
+			return fmt.Errorf("resolve module path failed: %w", err)
+		}
+
+		b.modulePath = module
+	}
+
+	b.written = map[string]struct{}{}
+	b.stats = Stats{}
+
+	// the entities are processed again from the spec on each Build.
+	b.data.Entities = nil
+
+	templates := []fs.FS{}
+
+	for _, ext := range b.extensions {
+		templates = append(templates, ext.Templates()...)
+	}
+
+	// the templates dirs are layered last, they override the templates of the extensions.
+	tpl, err := tmpl.New(b.funcMap(), append(templates, b.templates...)...)
+	if err != nil {
+		return fmt.Errorf("load templates failed: %w", err)
+	}
+
+	b.tpl = tpl
+
 	return nil
 }
 
+// Build generates the entities, the templates are rendered concurrently
+// and written in order, the files with an unchanged content are not written.
 func (b *Builder) Build() error {
-	b.processSpec()
+	start := time.Now()
+
+	if err := b.prepare(); err != nil {
+		return err
+	}
+
+	if err := b.processSpec(); err != nil {
+		return fmt.Errorf("process spec: %w", err)
+	}
+
+	for _, ext := range b.extensions {
+		if err := ext.Data(b.data); err != nil {
+			return fmt.Errorf("extension %T data: %w", ext, err)
+		}
+	}
 
 	if err := b.createFolders(); err != nil {
 		return fmt.Errorf("create destination structure folders: %w", err)
 	}
 
-	if err := b.generateClient(); err != nil {
-		return fmt.Errorf("generate client: %w", err)
-	}
+	jobs := b.jobs()
 
-	if err := b.generatePredicate(); err != nil {
-		return fmt.Errorf("generate predicate: %w", err)
+	if err := b.renderAll(jobs); err != nil {
+		return fmt.Errorf("render templates: %w", err)
 	}
 
-	for _, entity := range b.data.Entities {
-		if err := b.generateEntity(entity); err != nil {
-			return fmt.Errorf("generate entity: %w", err)
+	for _, job := range jobs {
+		if err := b.write(job); err != nil {
+			return err
 		}
 	}
 
+	if err := b.writeManifest(jobs); err != nil {
+		return err
+	}
+
+	if err := b.removeStale(); err != nil {
+		return fmt.Errorf("remove stale files: %w", err)
+	}
+
+	b.stats.Duration = time.Since(start)
+
 	return nil
 }
+
+// Stats returns the outcome of the last Build.
+func (b *Builder) Stats() Stats {
+	return b.stats
+}
@@ -1,8 +1,17 @@
 package builder
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
+	"ariga.io/atlas/sql/schema"
+	"github.com/euskadi31/entify/pkg/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,3 +25,571 @@ func TestBuilderGetDestFilename(t *testing.T) {
 
 	assert.Equal(t, "useractivate/user_activate.go", filename)
 }
+
+func TestBuilderProcessSpecConventions(t *testing.T) {
+	id := schema.NewStringColumn("id", "char")
+
+	users := schema.NewTable("users").
+		AddColumns(
+			id,
+			schema.NewTimeColumn("created_at", "timestamp"),
+			schema.NewNullTimeColumn("updated_at", "timestamp"),
+			schema.NewNullTimeColumn("deleted_at", "timestamp"),
+			schema.NewIntColumn("version", "int"),
+		).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	b := New(*schema.New("demo").AddTables(users))
+
+	assert.NoError(t, b.processSpec())
+
+	assert.Len(t, b.data.Entities, 1)
+	assert.Equal(t, "created_at", b.data.Entities[0].CreatedAt.Name)
+	assert.Equal(t, "updated_at", b.data.Entities[0].UpdatedAt.Name)
+	assert.Equal(t, "deleted_at", b.data.Entities[0].DeletedAt.Name)
+	assert.Equal(t, "version", b.data.Entities[0].Version.Name)
+
+	b = New(*schema.New("demo").AddTables(users), WithConfig(Config{}))
+
+	assert.NoError(t, b.processSpec())
+
+	assert.Nil(t, b.data.Entities[0].CreatedAt)
+	assert.Nil(t, b.data.Entities[0].UpdatedAt)
+	assert.Nil(t, b.data.Entities[0].DeletedAt)
+	assert.Nil(t, b.data.Entities[0].Version)
+}
+
+func TestBuilderProcessSpecTypeOverrides(t *testing.T) {
+	id := schema.NewStringColumn("id", "char", schema.StringSize(36))
+
+	users := schema.NewTable("users").
+		AddColumns(id, schema.NewDecimalColumn("balance", "decimal", schema.DecimalPrecision(10))).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	config := DefaultConfig()
+	config.TypeOverrides = TypeOverrides{
+		{SQLType: "char(36)", Type: "uuid.UUID", Package: "github.com/google/uuid", ScanValue: "uuid.MustParse(%s)"},
+		{Column: "users.balance", Type: "money.Money", Package: "example.com/money", ScanValue: "money.Money(%s)"},
+	}
+
+	b := New(*schema.New("demo").AddTables(users), WithConfig(config))
+
+	assert.NoError(t, b.processSpec())
+
+	entity := b.data.Entities[0]
+
+	assert.Equal(t, []string{"example.com/money", "github.com/google/uuid"}, entity.Imports)
+	assert.Equal(t, []string{"github.com/google/uuid"}, entity.PrimaryKeyImports)
+	assert.Equal(t, []string{"database/sql", "github.com/google/uuid", "time"}, entity.ClientImports)
+	assert.Equal(t, "uuid.UUID", entity.Fields[0].Type)
+	assert.Equal(t, "uuid.MustParse(value.String)", entity.Fields[0].ScanValue)
+	assert.Equal(t, "money.Money", entity.Fields[1].Type)
+	assert.Equal(t, "money.Money(value.String)", entity.Fields[1].ScanValue)
+}
+
+func TestBuilderProcessSpecTypeOverridesNotConvertible(t *testing.T) {
+	id := schema.NewStringColumn("id", "char", schema.StringSize(36))
+
+	users := schema.NewTable("users").
+		AddColumns(id).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	config := DefaultConfig()
+	config.TypeOverrides = TypeOverrides{
+		{SQLType: "char(36)", Type: "uuid.UUID", Package: "github.com/google/uuid"},
+	}
+
+	b := New(*schema.New("demo").AddTables(users), WithConfig(config))
+
+	assert.EqualError(t, b.processSpec(), "table users column id: type uuid.UUID is not convertible from sql.NullString, set scan_type or scan_value")
+}
+
+func TestBuilderProcessSpecTypeOverridesConventions(t *testing.T) {
+	id := schema.NewIntColumn("id", "bigint")
+
+	posts := schema.NewTable("posts").
+		AddColumns(
+			id,
+			schema.NewTimeColumn("created_at", "timestamp"),
+			schema.NewIntColumn("version", "int"),
+		).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	config := DefaultConfig()
+	config.TypeOverrides = TypeOverrides{
+		{Column: "posts.created_at", Type: "time.Time", ScanType: "sql.NullTime"},
+		{Column: "posts.version", Type: "int64"},
+	}
+
+	b := New(*schema.New("demo").AddTables(posts), WithConfig(config))
+
+	assert.NoError(t, b.processSpec())
+
+	entity := b.data.Entities[0]
+
+	assert.NotNil(t, entity.CreatedAt)
+	assert.NotNil(t, entity.Version)
+	assert.Equal(t, "int64", entity.Version.Type)
+}
+
+func TestBuilderProcessSpecNullableMode(t *testing.T) {
+	id := schema.NewStringColumn("id", "char")
+
+	users := schema.NewTable("users").
+		AddColumns(id, schema.NewNullStringColumn("firstname", "varchar")).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	for mode, expected := range map[NullableMode]string{
+		NullableModeValue:   "string",
+		NullableModePointer: "*string",
+		NullableModeSQLNull: "sql.Null[string]",
+	} {
+		config := DefaultConfig()
+		config.NullableMode = mode
+
+		b := New(*schema.New("demo").AddTables(users), WithConfig(config))
+
+		assert.NoError(t, b.processSpec())
+
+		assert.Equal(t, "string", b.data.Entities[0].Fields[0].StructType)
+		assert.Equal(t, expected, b.data.Entities[0].Fields[1].StructType)
+	}
+
+	config := DefaultConfig()
+	config.NullableMode = "ref"
+
+	b := New(*schema.New("demo").AddTables(users), WithConfig(config))
+
+	assert.EqualError(t, b.processSpec(), "nullable mode ref is not supported")
+}
+
+func TestBuilderProcessSpecInitialisms(t *testing.T) {
+	id := schema.NewIntColumn("id", "int")
+
+	keys := schema.NewTable("api_keys").
+		AddColumns(id, schema.NewStringColumn("api_key", "varchar")).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	config := DefaultConfig()
+	config.Initialisms = []string{"api"}
+
+	b := New(*schema.New("demo").AddTables(keys), WithConfig(config))
+
+	assert.NoError(t, b.processSpec())
+
+	assert.Equal(t, "apiKey", b.data.Entities[0].VariableName)
+	assert.Equal(t, "APIKey", b.data.Entities[0].Fields[1].PropertyName)
+
+	b = New(*schema.New("demo").AddTables(keys))
+
+	assert.NoError(t, b.processSpec())
+
+	assert.Equal(t, "ApiKey", b.data.Entities[0].Fields[1].PropertyName)
+}
+
+func TestBuilderProcessSpecKeylessTable(t *testing.T) {
+	logs := schema.NewTable("logs").AddColumns(schema.NewStringColumn("msg", "text"))
+
+	b := New(*schema.New("demo").AddTables(logs), WithFS(NewMemFS()), WithModulePath("github.com/acme/app/entity"))
+
+	assert.EqualError(t, b.Build(), "process spec: table logs has no primary key, exclude it from the generation")
+
+	config := DefaultConfig()
+	config.ExcludeTables = []string{"logs"}
+
+	b = New(*schema.New("demo").AddTables(logs), WithFS(NewMemFS()), WithModulePath("github.com/acme/app/entity"), WithConfig(config))
+
+	assert.NoError(t, b.Build())
+}
+
+func TestBuilderProcessSpecTables(t *testing.T) {
+	spec := schema.New("demo")
+
+	for _, name := range []string{"users", "posts", "schema_migrations"} {
+		id := schema.NewIntColumn("id", "int")
+
+		spec.AddTables(schema.NewTable(name).AddColumns(id).SetPrimaryKey(schema.NewPrimaryKey(id)))
+	}
+
+	config := DefaultConfig()
+	config.ExcludeTables = []string{"schema_*"}
+
+	b := New(*spec, WithConfig(config))
+
+	assert.NoError(t, b.processSpec())
+	assert.Len(t, b.data.Entities, 2)
+
+	config.IncludeTables = []string{"users"}
+
+	b = New(*spec, WithConfig(config))
+
+	assert.NoError(t, b.processSpec())
+	assert.Len(t, b.data.Entities, 1)
+	assert.Equal(t, "User", b.data.Entities[0].StructName)
+
+	config.IncludeTables = []string{"[users"}
+
+	b = New(*spec, WithConfig(config))
+
+	assert.EqualError(t, b.processSpec(), "invalid table pattern [users: syntax error in pattern")
+}
+
+func TestBuilderBuild(t *testing.T) {
+	id := schema.NewIntColumn("id", "int")
+
+	users := schema.NewTable("users").
+		AddColumns(id, schema.NewStringColumn("email", "varchar")).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	files := NewMemFS()
+
+	b := New(
+		*schema.New("demo").AddTables(users),
+		WithFS(files),
+		WithModulePath("github.com/acme/app/model"),
+		WithPackage("model"),
+	)
+
+	assert.NoError(t, b.Build())
+
+	assert.Equal(t, []string{
+		"client.go",
+		"internal/internal.go",
+		"predicate/predicate.go",
+		"user.go",
+		"user/user.go",
+		"user/where.go",
+		"user_client.go",
+		"user_create.go",
+		"user_delete.go",
+		"user_mutation.go",
+		"user_query.go",
+		"user_update.go",
+	}, files.Names())
+
+	content, err := files.ReadFile("user_query.go")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"github.com/acme/app/model/user"`)
+}
+
+func TestBuilderBuildReceiverShadowing(t *testing.T) {
+	spec := schema.New("demo")
+
+	for _, name := range []string{"items", "values"} {
+		id := schema.NewIntColumn("id", "int")
+
+		spec.AddTables(schema.NewTable(name).
+			AddColumns(id, schema.NewNullStringColumn("name", "varchar")).
+			SetPrimaryKey(schema.NewPrimaryKey(id)))
+	}
+
+	config := DefaultConfig()
+	config.NullableMode = NullableModePointer
+
+	files := NewMemFS()
+
+	b := New(
+		*spec,
+		WithFS(files),
+		WithModulePath("github.com/acme/app/entity"),
+		WithConfig(config),
+	)
+
+	assert.NoError(t, b.Build())
+
+	receivers := map[string]string{"item": "i", "value": "v"}
+
+	for _, name := range files.Names() {
+		prefix := strings.SplitN(strings.TrimSuffix(name, ".go"), "_", 2)[0]
+
+		receiver, ok := receivers[prefix]
+		if !ok {
+			continue
+		}
+
+		content, err := files.ReadFile(name)
+		assert.NoError(t, err)
+
+		file, err := parser.ParseFile(token.NewFileSet(), name, content, 0)
+		assert.NoError(t, err)
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+
+			// the entity variable is either the receiver or a local, it must be declared once.
+			declared := 0
+
+			if fn.Recv != nil {
+				for _, ident := range fn.Recv.List[0].Names {
+					if ident.Name == receiver {
+						declared++
+					}
+				}
+			}
+
+			ast.Inspect(fn.Body, func(node ast.Node) bool {
+				var idents []ast.Expr
+
+				switch stmt := node.(type) {
+				case *ast.AssignStmt:
+					if stmt.Tok == token.DEFINE {
+						idents = stmt.Lhs
+					}
+				case *ast.RangeStmt:
+					if stmt.Tok == token.DEFINE {
+						idents = []ast.Expr{stmt.Key, stmt.Value}
+					}
+				}
+
+				for _, expr := range idents {
+					if ident, ok := expr.(*ast.Ident); ok && ident.Name == receiver {
+						declared++
+					}
+				}
+
+				return true
+			})
+
+			assert.LessOrEqual(t, declared, 1, "%s: %s shadows the %s variable", name, fn.Name.Name, receiver)
+		}
+	}
+}
+
+func TestBuilderBuildWithoutOutput(t *testing.T) {
+	b := New(*schema.New("demo"))
+
+	assert.EqualError(t, b.Build(), "output is required, set it with WithOutput or WithFS")
+
+	b = New(*schema.New("demo"), WithFS(NewMemFS()))
+
+	assert.EqualError(t, b.Build(), "module path is required, set it with WithModulePath")
+}
+
+func TestBuilderBuildRemoveStale(t *testing.T) {
+	dir := t.TempDir()
+
+	id := schema.NewIntColumn("id", "int")
+
+	users := schema.NewTable("users").
+		AddColumns(id).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	generated := []byte(GeneratedHeader + ", DO NOT EDIT.\n\npackage entity\n")
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "post"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "post", "post.go"), generated, 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "post_client.go"), generated, 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "helper.go"), []byte("package entity\n"), 0600))
+
+	b := New(
+		*schema.New("demo").AddTables(users),
+		WithOutput(dir),
+		WithModulePath("github.com/acme/app/entity"),
+	)
+
+	assert.NoError(t, b.Build())
+
+	assert.NoDirExists(t, filepath.Join(dir, "post"))
+	assert.NoFileExists(t, filepath.Join(dir, "post_client.go"))
+	assert.FileExists(t, filepath.Join(dir, "helper.go"))
+	assert.FileExists(t, filepath.Join(dir, "user_client.go"))
+}
+
+func TestBuilderBuildNestedOutput(t *testing.T) {
+	dir := t.TempDir()
+
+	nested := New(
+		*goldenSpec(),
+		WithOutput(filepath.Join(dir, "sqlite")),
+		WithModulePath("github.com/acme/app/entify/sqlite"),
+		WithPackage("sqlite"),
+	)
+
+	assert.NoError(t, nested.Build())
+
+	b := New(
+		*goldenSpec(),
+		WithOutput(dir),
+		WithModulePath("github.com/acme/app/entify"),
+	)
+
+	assert.NoError(t, b.Build())
+	assert.Equal(t, 0, b.Stats().Removed)
+
+	assert.FileExists(t, filepath.Join(dir, "sqlite", "client.go"))
+	assert.FileExists(t, filepath.Join(dir, "sqlite", "user", "user.go"))
+}
+
+func TestBuilderBuildUnchanged(t *testing.T) {
+	dir := t.TempDir()
+
+	opts := []Option{
+		WithOutput(dir),
+		WithModulePath("github.com/acme/app/entity"),
+		WithWorkers(4),
+	}
+
+	b := New(*goldenSpec(), opts...)
+
+	assert.NoError(t, b.Build())
+	assert.Equal(t, 12, b.Stats().Written)
+	assert.Equal(t, 0, b.Stats().Unchanged)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "user.go"), []byte(GeneratedHeader+", DO NOT EDIT.\n"), 0600))
+
+	b = New(*goldenSpec(), opts...)
+
+	assert.NoError(t, b.Build())
+	assert.Equal(t, 1, b.Stats().Written)
+	assert.Equal(t, 11, b.Stats().Unchanged)
+	assert.Equal(t, 0, b.Stats().Removed)
+}
+
+func TestBuilderBuildTwice(t *testing.T) {
+	b := New(
+		*goldenSpec(),
+		WithOutput(t.TempDir()),
+		WithModulePath("github.com/acme/app/entity"),
+	)
+
+	assert.NoError(t, b.Build())
+	assert.Equal(t, 12, b.Stats().Written)
+
+	assert.NoError(t, b.Build())
+	assert.Len(t, b.data.Entities, 1)
+	assert.Equal(t, 0, b.Stats().Written)
+	assert.Equal(t, 12, b.Stats().Unchanged)
+	assert.Equal(t, 0, b.Stats().Removed)
+}
+
+func TestBuilderProcessSpecOrdering(t *testing.T) {
+	id := schema.NewIntColumn("id", "int")
+
+	users := schema.NewTable("users").
+		AddColumns(
+			id,
+			schema.NewStringColumn("name", "varchar"),
+			schema.NewTimeColumn("created_at", "timestamp"),
+			schema.NewStringColumn("email", "varchar"),
+		).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	accountID := schema.NewIntColumn("id", "int")
+
+	accounts := schema.NewTable("accounts").
+		AddColumns(accountID).
+		SetPrimaryKey(schema.NewPrimaryKey(accountID))
+
+	spec := schema.New("demo").AddTables(users, accounts)
+
+	names := func(fields []*types.Field) []string {
+		items := []string{}
+
+		for _, f := range fields {
+			items = append(items, f.Name)
+		}
+
+		return items
+	}
+
+	b := New(*spec)
+
+	assert.NoError(t, b.processSpec())
+	assert.Equal(t, "Account", b.data.Entities[0].StructName)
+	assert.Equal(t, "User", b.data.Entities[1].StructName)
+	assert.Equal(t, []string{"id", "name", "created_at", "email"}, names(b.data.Entities[1].Fields))
+
+	config := DefaultConfig()
+	config.ColumnOrder = ColumnOrderName
+
+	b = New(*spec, WithConfig(config))
+
+	assert.NoError(t, b.processSpec())
+	assert.Equal(t, []string{"id", "created_at", "email", "name"}, names(b.data.Entities[1].Fields))
+
+	config.ColumnOrder = "random"
+
+	b = New(*spec, WithConfig(config))
+
+	assert.EqualError(t, b.processSpec(), "column order random is not supported")
+}
+
+func TestBuilderBuildExtraTemplates(t *testing.T) {
+	templates := fstest.MapFS{
+		"__entity-file___query.go.tmpl": &fstest.MapFile{
+			Data: []byte("// Code generated by entify, DO NOT EDIT.\n\npackage {{.Package}}\n\n// {{.Entity.StructName}}Query is overridden.\ntype {{.Entity.StructName}}Query struct{}\n"),
+		},
+		"schema.graphql.tmpl": &fstest.MapFile{
+			Data: []byte("{{range .Entities}}type {{.StructName}}\n{{end}}"),
+		},
+		"__entity-package__/__entity-file___validate.go.tmpl": &fstest.MapFile{
+			Data: []byte("package {{.PackageName}}\n\nfunc Validate() error { return nil }\n"),
+		},
+	}
+
+	files := NewMemFS()
+
+	b := New(
+		*goldenSpec(),
+		WithFS(files),
+		WithModulePath("github.com/acme/app/entity"),
+		WithTemplates(templates),
+	)
+
+	assert.NoError(t, b.Build())
+
+	content, err := files.ReadFile("user_query.go")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "// UserQuery is overridden.")
+
+	content, err = files.ReadFile("schema.graphql")
+	assert.NoError(t, err)
+	assert.Equal(t, "type User\n", string(content))
+
+	content, err = files.ReadFile("user/user_validate.go")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "package user")
+
+	content, err = files.ReadFile(ManifestFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Code generated by entify, DO NOT EDIT.\nschema.graphql\nuser/user_validate.go\n", string(content))
+}
+
+func TestBuilderBuildRemoveStaleManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	templates := fstest.MapFS{
+		"schema.graphql.tmpl": &fstest.MapFile{
+			Data: []byte("{{range .Entities}}type {{.StructName}}\n{{end}}"),
+		},
+	}
+
+	opts := []Option{
+		WithOutput(dir),
+		WithModulePath("github.com/acme/app/entity"),
+	}
+
+	b := New(*goldenSpec(), append(opts, WithTemplates(templates))...)
+
+	assert.NoError(t, b.Build())
+
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	assert.NoError(t, err)
+	assert.Equal(t, "# Code generated by entify, DO NOT EDIT.\nschema.graphql\n", string(content))
+
+	names, err := GeneratedFiles(dir)
+	assert.NoError(t, err)
+	assert.Contains(t, names, ManifestFile)
+	assert.Contains(t, names, "schema.graphql")
+
+	b = New(*goldenSpec(), opts...)
+
+	assert.NoError(t, b.Build())
+	assert.Equal(t, 2, b.Stats().Removed)
+
+	assert.NoFileExists(t, filepath.Join(dir, "schema.graphql"))
+	assert.NoFileExists(t, filepath.Join(dir, ManifestFile))
+}
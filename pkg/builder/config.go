@@ -0,0 +1,120 @@
+package builder
+
+import (
+	"fmt"
+	"path"
+)
+
+// NullableMode is the Go representation of the nullable columns.
+type NullableMode string
+
+const (
+	// NullableModeValue generates nullable fields as values, NULL is read as the zero value.
+	NullableModeValue NullableMode = "value"
+
+	// NullableModePointer generates nullable fields as pointers, NULL is read as nil.
+	NullableModePointer NullableMode = "pointer"
+
+	// NullableModeSQLNull generates nullable fields as sql.Null[T], NULL is read as an invalid value.
+	NullableModeSQLNull NullableMode = "sql"
+)
+
+// ColumnOrder is the order of the fields of the entities.
+type ColumnOrder string
+
+const (
+	// ColumnOrderSpec keeps the order of the columns in the spec.
+	ColumnOrderSpec ColumnOrder = "spec"
+
+	// ColumnOrderName sorts the columns by name, after the primary key columns.
+	ColumnOrderName ColumnOrder = "name"
+)
+
+// Config holds the conventions applied when generating entities.
+type Config struct {
+	// CreatedAtColumn is the name of the time column set on creation.
+	CreatedAtColumn string
+
+	// UpdatedAtColumn is the name of the time column set on creation and on every update.
+	UpdatedAtColumn string
+
+	// DeletedAtColumn is the name of the nullable time column used to soft delete rows.
+	DeletedAtColumn string
+
+	// VersionColumn is the name of the integer column used for optimistic locking.
+	VersionColumn string
+
+	// NullableMode is the Go representation of the nullable columns.
+	NullableMode NullableMode
+
+	// ColumnOrder is the order of the fields of the entities, the entities are sorted by table name.
+	ColumnOrder ColumnOrder
+
+	// TypeOverrides replaces the Go type of the matching columns.
+	TypeOverrides TypeOverrides
+
+	// IncludeTables are the glob patterns of the tables to generate, all the tables when empty.
+	IncludeTables []string
+
+	// ExcludeTables are the glob patterns of the tables to skip.
+	ExcludeTables []string
+
+	// Initialisms are the words written in upper case in the Go names, added to id, url and uri, e.g. api => API.
+	Initialisms []string
+}
+
+// TableEnabled reports whether an entity is generated for the table.
+func (c Config) TableEnabled(name string) bool {
+	for _, pattern := range c.ExcludeTables {
+		if ok, _ := path.Match(pattern, name); ok {
+			return false
+		}
+	}
+
+	if len(c.IncludeTables) == 0 {
+		return true
+	}
+
+	for _, pattern := range c.IncludeTables {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the table patterns, the nullable mode and the column order.
+func (c Config) Validate() error {
+	switch c.NullableMode {
+	case "", NullableModeValue, NullableModePointer, NullableModeSQLNull:
+	default:
+		return fmt.Errorf("nullable mode %s is not supported", c.NullableMode)
+	}
+
+	switch c.ColumnOrder {
+	case "", ColumnOrderSpec, ColumnOrderName:
+	default:
+		return fmt.Errorf("column order %s is not supported", c.ColumnOrder)
+	}
+
+	for _, pattern := range append(append([]string{}, c.IncludeTables...), c.ExcludeTables...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid table pattern %s: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// DefaultConfig returns the default conventions.
+func DefaultConfig() Config {
+	return Config{
+		CreatedAtColumn: "created_at",
+		UpdatedAtColumn: "updated_at",
+		DeletedAtColumn: "deleted_at",
+		VersionColumn:   "version",
+		NullableMode:    NullableModeValue,
+		ColumnOrder:     ColumnOrderSpec,
+	}
+}
@@ -0,0 +1,286 @@
+package builder
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// GeneratedHeader starts the first line of the generated files, the files without it are never modified.
+const GeneratedHeader = "// Code generated by entify"
+
+// ManifestFile lists the generated files without GeneratedHeader, one slash separated name per line,
+// it is written in the output root by Build when an extra template renders such a file.
+const ManifestFile = ".entify-manifest"
+
+const manifestHeader = "# Code generated by entify, DO NOT EDIT."
+
+// ChangeKind is the kind of change made to a file by a generation.
+type ChangeKind string
+
+const (
+	// ChangeCreate is a generated file not in the output yet.
+	ChangeCreate ChangeKind = "create"
+
+	// ChangeModify is a generated file with a new content.
+	ChangeModify ChangeKind = "modify"
+
+	// ChangeDelete is a stale generated file, no longer generated.
+	ChangeDelete ChangeKind = "delete"
+)
+
+// Change is a difference between the generated files and the files of the output dir.
+type Change struct {
+	// Name is the slash separated name of the file, relative to the output dir.
+	Name string
+
+	Kind ChangeKind
+	Old  []byte
+	New  []byte
+}
+
+// Unified returns the unified diff of the change, the file names are prefixed with dir.
+func (c *Change) Unified(dir string) (string, error) {
+	name := path.Join(filepath.ToSlash(dir), c.Name)
+	from, to := "a/"+name, "b/"+name
+
+	switch c.Kind {
+	case ChangeCreate:
+		from = "/dev/null"
+	case ChangeDelete:
+		to = "/dev/null"
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(c.Old),
+		B:        splitLines(c.New),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("diff %s file failed: %w", name, err)
+	}
+
+	return diff, nil
+}
+
+// Diff returns the changes made by writing the generated files in dir, sorted by name.
+// The generated files of dir no longer generated are deleted.
+func Diff(dir string, files *MemFS) ([]*Change, error) {
+	changes := []*Change{}
+	generated := map[string]struct{}{}
+
+	for _, name := range files.Names() {
+		generated[name] = struct{}{}
+
+		content, err := files.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		old, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, &Change{
+				Name: name,
+				Kind: ChangeCreate,
+				New:  content,
+			})
+		case err != nil:
+			return nil, fmt.Errorf("read %s file failed: %w", name, err)
+		case !bytes.Equal(old, content):
+			changes = append(changes, &Change{
+				Name: name,
+				Kind: ChangeModify,
+				Old:  old,
+				New:  content,
+			})
+		}
+	}
+
+	stale, err := GeneratedFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range stale {
+		if _, ok := generated[name]; ok {
+			continue
+		}
+
+		old, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, fmt.Errorf("read %s file failed: %w", name, err)
+		}
+
+		changes = append(changes, &Change{
+			Name: name,
+			Kind: ChangeDelete,
+			Old:  old,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes, nil
+}
+
+// GeneratedFiles returns the sorted names of the Go files of dir and its sub dirs starting with GeneratedHeader,
+// and of the ManifestFile of dir with the existing files it lists, the names are slash separated and relative to dir.
+// The sub dirs holding another output, with a ManifestFile or a generated client.go, are skipped.
+func GeneratedFiles(dir string) ([]string, error) {
+	names, err := manifestFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = filepath.WalkDir(dir, func(filename string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && filename == dir {
+				return fs.SkipDir
+			}
+
+			return err
+		}
+
+		if d.IsDir() {
+			// a nested output, e.g. another schema generated in a sub dir, is owned by its own build.
+			if filename != dir {
+				ok, err := isOutputRoot(filename)
+				if err != nil {
+					return err
+				}
+
+				if ok {
+					return fs.SkipDir
+				}
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(d.Name(), ".go") {
+			return nil
+		}
+
+		ok, err := isGenerated(filename)
+		if err != nil || !ok {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, filename)
+		if err != nil {
+			return fmt.Errorf("get %s path relative to %s failed: %w", filename, dir, err)
+		}
+
+		names = append(names, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list generated files of %s failed: %w", dir, err)
+	}
+
+	sort.Strings(names)
+
+	// a file listed in the manifest may start with GeneratedHeader too.
+	return slices.Compact(names), nil
+}
+
+// manifestFiles returns the ManifestFile of dir and the existing files it lists, none without ManifestFile.
+func manifestFiles(dir string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read %s manifest failed: %w", dir, err)
+	}
+
+	names := []string{ManifestFile}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		name := strings.TrimSpace(line)
+
+		// the names out of dir are ignored, the stale files are removed.
+		if name == "" || strings.HasPrefix(name, "#") || !fs.ValidPath(name) || name == ManifestFile {
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, fmt.Errorf("stat %s file failed: %w", name, err)
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// isOutputRoot reports if dir is the root of a generated output.
+func isOutputRoot(dir string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err == nil {
+		return true, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return false, fmt.Errorf("stat %s manifest failed: %w", dir, err)
+	}
+
+	ok, err := isGenerated(filepath.Join(dir, "client.go"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	return ok, err
+}
+
+func isGenerated(filename string) (bool, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return false, fmt.Errorf("open %s file failed: %w", filename, err)
+	}
+
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && line == "" {
+		return false, nil
+	}
+
+	return strings.HasPrefix(line, GeneratedHeader), nil
+}
+
+// splitLines splits the content in lines ending with a line feed.
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n"
+
+	return lines
+}
@@ -0,0 +1,135 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "post"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "client.go"), []byte(GeneratedHeader+", DO NOT EDIT.\n\npackage entity\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "user.go"), []byte(GeneratedHeader+", DO NOT EDIT.\n\npackage entity\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "post", "post.go"), []byte(GeneratedHeader+", DO NOT EDIT.\n\npackage post\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "helper.go"), []byte("package entity\n"), 0600))
+
+	files := NewMemFS()
+
+	assert.NoError(t, files.WriteFile("client.go", []byte(GeneratedHeader+", DO NOT EDIT.\n\npackage entity\n"), 0644))
+	assert.NoError(t, files.WriteFile("user.go", []byte(GeneratedHeader+", DO NOT EDIT.\n\npackage entity\n\ntype User struct{}\n"), 0644))
+	assert.NoError(t, files.WriteFile("user/user.go", []byte(GeneratedHeader+", DO NOT EDIT.\n\npackage user\n"), 0644))
+
+	changes, err := Diff(dir, files)
+	assert.NoError(t, err)
+
+	assert.Len(t, changes, 3)
+
+	assert.Equal(t, "post/post.go", changes[0].Name)
+	assert.Equal(t, ChangeDelete, changes[0].Kind)
+
+	assert.Equal(t, "user.go", changes[1].Name)
+	assert.Equal(t, ChangeModify, changes[1].Kind)
+
+	assert.Equal(t, "user/user.go", changes[2].Name)
+	assert.Equal(t, ChangeCreate, changes[2].Kind)
+
+	diff, err := changes[1].Unified("entity")
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "--- a/entity/user.go\n+++ b/entity/user.go\n")
+	assert.Contains(t, diff, "+type User struct{}\n")
+}
+
+// TestDiffDeleteBuild checks the stale files reported by Diff are the ones removed by Build.
+func TestDiffDeleteBuild(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "post"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "post", "post.go"), []byte(GeneratedHeader+", DO NOT EDIT.\n\npackage post\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "post_client.go"), []byte(GeneratedHeader+", DO NOT EDIT.\n\npackage entity\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "helper.go"), []byte("package entity\n"), 0600))
+
+	id := schema.NewIntColumn("id", "int")
+
+	spec := schema.New("demo").AddTables(schema.NewTable("users").AddColumns(id).SetPrimaryKey(schema.NewPrimaryKey(id)))
+
+	files := NewMemFS()
+
+	assert.NoError(t, New(*spec, WithFS(files), WithModulePath("github.com/acme/app/entity")).Build())
+
+	changes, err := Diff(dir, files)
+	assert.NoError(t, err)
+
+	deleted := []string{}
+
+	for _, change := range changes {
+		if change.Kind == ChangeDelete {
+			deleted = append(deleted, change.Name)
+		}
+	}
+
+	assert.Equal(t, []string{"post/post.go", "post_client.go"}, deleted)
+
+	b := New(*spec, WithOutput(dir), WithModulePath("github.com/acme/app/entity"))
+
+	assert.NoError(t, b.Build())
+	assert.Equal(t, len(deleted), b.Stats().Removed)
+
+	for _, name := range deleted {
+		assert.NoFileExists(t, filepath.Join(dir, filepath.FromSlash(name)))
+	}
+
+	changes, err = Diff(dir, files)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestGeneratedFilesManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFile), []byte(manifestHeader+"\nschema.graphql\nmissing.graphql\n../outside.graphql\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "schema.graphql"), []byte("type User\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "user.go"), []byte(GeneratedHeader+", DO NOT EDIT.\n\npackage entity\n"), 0600))
+
+	names, err := GeneratedFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ManifestFile, "schema.graphql", "user.go"}, names)
+}
+
+func TestGeneratedFilesNestedOutput(t *testing.T) {
+	dir := t.TempDir()
+	header := []byte(GeneratedHeader + ", DO NOT EDIT.\n\npackage entity\n")
+
+	for _, name := range []string{"client.go", "user/user.go", "sqlite/client.go", "sqlite/user/user.go", "graphql/schema.go"} {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filename), 0750))
+		assert.NoError(t, os.WriteFile(filename, header, 0600))
+	}
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "graphql", ManifestFile), []byte(manifestHeader+"\n"), 0600))
+
+	names, err := GeneratedFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"client.go", "user/user.go"}, names)
+
+	names, err = GeneratedFiles(filepath.Join(dir, "sqlite"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"client.go", "user/user.go"}, names)
+}
+
+func TestGeneratedFilesMissingDir(t *testing.T) {
+	names, err := GeneratedFiles(filepath.Join(t.TempDir(), "entity"))
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestSplitLines(t *testing.T) {
+	assert.Nil(t, splitLines(nil))
+	assert.Equal(t, []string{"a\n", "b\n"}, splitLines([]byte("a\nb\n")))
+	assert.Equal(t, []string{"a\n", "b\n"}, splitLines([]byte("a\nb")))
+}
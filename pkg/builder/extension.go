@@ -0,0 +1,39 @@
+package builder
+
+import (
+	"io/fs"
+
+	"github.com/euskadi31/entify/pkg/types"
+)
+
+// Extension extends the generation without forking entify, e.g. to generate GraphQL schemas,
+// validators or metrics. Embed DefaultExtension to implement only the needed hooks.
+type Extension interface {
+	// Data mutates the data of the entities before the rendering.
+	Data(data *types.Data) error
+
+	// Templates returns the templates of the extension, with the conventions of the templates dir:
+	// they override the embedded templates by path or are rendered as extra files.
+	Templates() []fs.FS
+
+	// File post-processes a rendered file, the name is relative to the output and
+	// the content of the Go files is formatted. It may be called concurrently.
+	File(name string, content []byte) ([]byte, error)
+}
+
+// DefaultExtension is a no-op Extension.
+type DefaultExtension struct{}
+
+func (DefaultExtension) Data(data *types.Data) error {
+	return nil
+}
+
+func (DefaultExtension) Templates() []fs.FS {
+	return nil
+}
+
+func (DefaultExtension) File(name string, content []byte) ([]byte, error) {
+	return content, nil
+}
+
+var _ Extension = (*DefaultExtension)(nil)
@@ -0,0 +1,101 @@
+package builder
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/euskadi31/entify/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type graphqlExtension struct {
+	DefaultExtension
+
+	err error
+}
+
+func (e *graphqlExtension) Data(data *types.Data) error {
+	for _, entity := range data.Entities {
+		entity.StructName = "GQL" + entity.StructName
+	}
+
+	return e.err
+}
+
+func (e *graphqlExtension) Templates() []fs.FS {
+	return []fs.FS{
+		fstest.MapFS{
+			"schema.graphql.tmpl": &fstest.MapFile{
+				Data: []byte("{{range .Entities}}type {{.StructName}}\n{{end}}"),
+			},
+		},
+	}
+}
+
+func (e *graphqlExtension) File(name string, content []byte) ([]byte, error) {
+	if !strings.HasSuffix(name, ".graphql") {
+		return content, nil
+	}
+
+	return append([]byte("# generated\n"), content...), nil
+}
+
+func TestBuilderBuildExtensions(t *testing.T) {
+	files := NewMemFS()
+
+	b := New(
+		*goldenSpec(),
+		WithFS(files),
+		WithModulePath("github.com/acme/app/entity"),
+		WithExtensions(&graphqlExtension{}),
+	)
+
+	assert.NoError(t, b.Build())
+
+	content, err := files.ReadFile("schema.graphql")
+	assert.NoError(t, err)
+	assert.Equal(t, "# generated\ntype GQLUser\n", string(content))
+
+	content, err = files.ReadFile("user.go")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "type GQLUser struct")
+}
+
+func TestBuilderBuildExtensionsTemplatesOverride(t *testing.T) {
+	templates := fstest.MapFS{
+		"schema.graphql.tmpl": &fstest.MapFile{
+			Data: []byte("overridden\n"),
+		},
+	}
+
+	files := NewMemFS()
+
+	b := New(
+		*goldenSpec(),
+		WithFS(files),
+		WithModulePath("github.com/acme/app/entity"),
+		WithTemplates(templates),
+		WithExtensions(&graphqlExtension{}),
+	)
+
+	assert.NoError(t, b.Build())
+
+	content, err := files.ReadFile("schema.graphql")
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasSuffix(content, []byte("overridden\n")))
+}
+
+func TestBuilderBuildExtensionsError(t *testing.T) {
+	b := New(
+		*goldenSpec(),
+		WithFS(NewMemFS()),
+		WithModulePath("github.com/acme/app/entity"),
+		WithExtensions(&graphqlExtension{err: errors.New("boom")}),
+	)
+
+	assert.EqualError(t, b.Build(), "extension *builder.graphqlExtension data: boom")
+}
@@ -0,0 +1,301 @@
+package builder
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FS is the filesystem the generated files are written to,
+// the names are slash separated and relative to the root of the output.
+type FS interface {
+	MkdirAll(name string, perm fs.FileMode) error
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+// ReadFileFS is a FS reading its files, Build does not write the files with an unchanged content.
+type ReadFileFS interface {
+	FS
+
+	ReadFile(name string) ([]byte, error)
+}
+
+// CleanFS is a FS listing and removing its generated files, Build removes the stale ones.
+type CleanFS interface {
+	FS
+
+	// GeneratedFiles returns the names of the generated files, see GeneratedFiles.
+	GeneratedFiles() ([]string, error)
+
+	// Remove removes the file and its parent dirs left empty.
+	Remove(name string) error
+}
+
+type dirFS string
+
+// DirFS returns a FS writing the files in the dir of the disk.
+func DirFS(dir string) FS {
+	return dirFS(dir)
+}
+
+func (d dirFS) MkdirAll(name string, perm fs.FileMode) error {
+	if err := os.MkdirAll(d.join(name), perm); err != nil {
+		return fmt.Errorf("mkdir failed: %w", err)
+	}
+
+	return nil
+}
+
+func (d dirFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	// the dirs of the extra templates are not created by Build.
+	if err := os.MkdirAll(filepath.Dir(d.join(name)), 0755); err != nil {
+		return fmt.Errorf("mkdir failed: %w", err)
+	}
+
+	if err := os.WriteFile(d.join(name), data, perm); err != nil {
+		return fmt.Errorf("write file failed: %w", err)
+	}
+
+	return nil
+}
+
+func (d dirFS) ReadFile(name string) ([]byte, error) {
+	b, err := os.ReadFile(d.join(name))
+	if err != nil {
+		return nil, fmt.Errorf("read file failed: %w", err)
+	}
+
+	return b, nil
+}
+
+func (d dirFS) GeneratedFiles() ([]string, error) {
+	return GeneratedFiles(string(d))
+}
+
+func (d dirFS) Remove(name string) error {
+	if err := os.Remove(d.join(name)); err != nil {
+		return fmt.Errorf("remove file failed: %w", err)
+	}
+
+	for dir := path.Dir(path.Clean(name)); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		entries, err := os.ReadDir(d.join(dir))
+		if err != nil {
+			return fmt.Errorf("read dir failed: %w", err)
+		}
+
+		if len(entries) > 0 {
+			break
+		}
+
+		if err := os.Remove(d.join(dir)); err != nil {
+			return fmt.Errorf("remove dir failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (d dirFS) join(name string) string {
+	return filepath.Join(string(d), filepath.FromSlash(name))
+}
+
+// MemFS is an in-memory FS, e.g. to test the generated files.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemFS returns an empty in-memory FS.
+func NewMemFS() *MemFS {
+	return &MemFS{
+		files: map[string][]byte{},
+	}
+}
+
+func (m *MemFS) MkdirAll(name string, perm fs.FileMode) error {
+	return nil
+}
+
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.files[path.Clean(name)] = append([]byte(nil), data...)
+
+	return nil
+}
+
+// ReadFile returns the content of a written file.
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.files[path.Clean(name)]
+	if !ok {
+		return nil, fmt.Errorf("read %s file failed: %w", name, fs.ErrNotExist)
+	}
+
+	return data, nil
+}
+
+// Names returns the sorted names of the written files.
+func (m *MemFS) Names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.files))
+
+	for name := range m.files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// TarFS writes the files in a tar archive, gzipped or not, it must be closed to flush the archive.
+type TarFS struct {
+	mu      sync.Mutex
+	gz      *gzip.Writer
+	tw      *tar.Writer
+	modTime time.Time
+}
+
+// NewTarFS returns a FS writing a tar archive to w, gzipped when compress is true.
+func NewTarFS(w io.Writer, compress bool) *TarFS {
+	a := &TarFS{
+		modTime: time.Now(),
+	}
+
+	if compress {
+		a.gz = gzip.NewWriter(w)
+		w = a.gz
+	}
+
+	a.tw = tar.NewWriter(w)
+
+	return a
+}
+
+func (a *TarFS) MkdirAll(name string, perm fs.FileMode) error {
+	return nil
+}
+
+func (a *TarFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Clean(name),
+		Size:     int64(len(data)),
+		Mode:     int64(perm),
+		ModTime:  a.modTime,
+	}); err != nil {
+		return fmt.Errorf("write %s header failed: %w", name, err)
+	}
+
+	if _, err := a.tw.Write(data); err != nil {
+		return fmt.Errorf("write %s file failed: %w", name, err)
+	}
+
+	return nil
+}
+
+// Close flushes the archive, the underlying writer is not closed.
+func (a *TarFS) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return fmt.Errorf("close tar failed: %w", err)
+	}
+
+	if a.gz != nil {
+		if err := a.gz.Close(); err != nil {
+			return fmt.Errorf("close gzip failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ZipFS writes the files in a zip archive, it must be closed to flush the archive.
+type ZipFS struct {
+	mu      sync.Mutex
+	zw      *zip.Writer
+	modTime time.Time
+}
+
+// NewZipFS returns a FS writing a zip archive to w.
+func NewZipFS(w io.Writer) *ZipFS {
+	return &ZipFS{
+		zw:      zip.NewWriter(w),
+		modTime: time.Now(),
+	}
+}
+
+func (a *ZipFS) MkdirAll(name string, perm fs.FileMode) error {
+	return nil
+}
+
+func (a *ZipFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	header := &zip.FileHeader{
+		Name:     path.Clean(name),
+		Method:   zip.Deflate,
+		Modified: a.modTime,
+	}
+
+	header.SetMode(perm)
+
+	f, err := a.zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("write %s header failed: %w", name, err)
+	}
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("write %s file failed: %w", name, err)
+	}
+
+	return nil
+}
+
+// Close flushes the archive, the underlying writer is not closed.
+func (a *ZipFS) Close() error {
+	if err := a.zw.Close(); err != nil {
+		return fmt.Errorf("close zip failed: %w", err)
+	}
+
+	return nil
+}
+
+type subFS struct {
+	fs  FS
+	dir string
+}
+
+// SubFS returns a FS writing the files in the dir of fsys.
+func SubFS(fsys FS, dir string) FS {
+	return &subFS{
+		fs:  fsys,
+		dir: dir,
+	}
+}
+
+func (s *subFS) MkdirAll(name string, perm fs.FileMode) error {
+	return s.fs.MkdirAll(path.Join(s.dir, name), perm)
+}
+
+func (s *subFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return s.fs.WriteFile(path.Join(s.dir, name), data, perm)
+}
@@ -0,0 +1,63 @@
+package builder
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirFS(t *testing.T) {
+	dir := t.TempDir()
+	fsys := DirFS(dir)
+
+	assert.NoError(t, fsys.MkdirAll("user", 0755))
+	assert.NoError(t, fsys.WriteFile("user/user.go", []byte("package user\n"), 0644))
+
+	content, err := os.ReadFile(filepath.Join(dir, "user", "user.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package user\n", string(content))
+}
+
+func TestTarFS(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	fsys := NewTarFS(buf, true)
+
+	assert.NoError(t, SubFS(fsys, "billing").WriteFile("user/user.go", []byte("package user\n"), 0644))
+	assert.NoError(t, fsys.Close())
+
+	gz, err := gzip.NewReader(buf)
+	assert.NoError(t, err)
+
+	tr := tar.NewReader(gz)
+
+	header, err := tr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "billing/user/user.go", header.Name)
+
+	content, err := io.ReadAll(tr)
+	assert.NoError(t, err)
+	assert.Equal(t, "package user\n", string(content))
+
+	_, err = tr.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestZipFS(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	fsys := NewZipFS(buf)
+
+	assert.NoError(t, fsys.WriteFile("client.go", []byte("package entity\n"), 0644))
+	assert.NoError(t, fsys.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, zr.File, 1)
+	assert.Equal(t, "client.go", zr.File[0].Name)
+}
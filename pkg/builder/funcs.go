@@ -0,0 +1,30 @@
+package builder
+
+import (
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+)
+
+// funcMap returns the naming functions available in the templates, with the initialisms of the builder.
+func (b *Builder) funcMap() template.FuncMap {
+	return template.FuncMap{
+		"receiver":          TableNameToReceiver,
+		"structName":        TableNameToStructName,
+		"fileName":          TableNameToFileName,
+		"packageName":       TableNameToPackageName,
+		"variableName":      b.names.tableVariableName,
+		"propertyName":      b.names.propertyName,
+		"fieldVariableName": b.names.columnVariableName,
+		"enumConstName":     EnumValueToConstName,
+		"camel":             strcase.ToCamel,
+		"lowerCamel":        strcase.ToLowerCamel,
+		"snake":             strcase.ToSnake,
+		"pluralize": func(s string) string {
+			return pluralizeClient.Plural(s)
+		},
+		"singularize": func(s string) string {
+			return pluralizeClient.Singular(s)
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package builder
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"ariga.io/atlas/sql/schema"
+	tmpl "github.com/euskadi31/entify/pkg/template"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderFuncMap(t *testing.T) {
+	config := DefaultConfig()
+	config.Initialisms = []string{"api"}
+
+	b := New(*schema.New("demo"), WithConfig(config))
+
+	engine, err := tmpl.New(b.funcMap(), fstest.MapFS{
+		"names.tmpl": &fstest.MapFile{
+			Data: []byte(`{{structName "users_activations"}} {{receiver "users_activations"}} {{propertyName "user_id"}} {{propertyName "api_key"}} {{pluralize "user"}} {{snake "UserActivation"}}`),
+		},
+	})
+	assert.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+
+	assert.NoError(t, engine.ExecuteTemplate(buf, "names.tmpl", nil))
+	assert.Equal(t, "UserActivation ua UserID APIKey users user_activation", buf.String())
+}
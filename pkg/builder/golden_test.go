@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func goldenSpec() *schema.Schema {
+	id := schema.NewIntColumn("id", "bigint")
+	email := schema.NewStringColumn("email", "varchar", schema.StringSize(255))
+
+	users := schema.NewTable("users").
+		AddColumns(
+			id,
+			email,
+			schema.NewNullStringColumn("nickname", "varchar", schema.StringSize(90)),
+			schema.NewEnumColumn("status", schema.EnumValues("pending", "active")),
+			schema.NewNullEnumColumn("role", schema.EnumValues("admin", "member")),
+			schema.NewTimeColumn("created_at", "timestamp"),
+			schema.NewNullTimeColumn("updated_at", "timestamp"),
+			schema.NewIntColumn("version", "int"),
+		).
+		SetPrimaryKey(schema.NewPrimaryKey(id))
+
+	return schema.New("demo").AddTables(users)
+}
+
+// TestBuilderBuildGolden compares the generated files with testdata/golden, run go test -update to refresh them.
+func TestBuilderBuildGolden(t *testing.T) {
+	files := NewMemFS()
+
+	b := New(
+		*goldenSpec(),
+		WithFS(files),
+		WithModulePath("github.com/acme/app/entity"),
+	)
+
+	assert.NoError(t, b.Build())
+
+	dir := filepath.Join("testdata", "golden")
+
+	for _, name := range files.Names() {
+		content, err := files.ReadFile(name)
+		assert.NoError(t, err)
+
+		filename := filepath.Join(dir, filepath.FromSlash(name)+".golden")
+
+		if *update {
+			assert.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+			assert.NoError(t, os.WriteFile(filename, content, 0600))
+
+			continue
+		}
+
+		expected, err := os.ReadFile(filename)
+		if !assert.NoError(t, err, "golden file of %s not found, run go test -update", name) {
+			continue
+		}
+
+		assert.Equal(t, string(expected), string(content), "%s differs from its golden file", name)
+	}
+}
@@ -0,0 +1,60 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+// ModulePath returns the import path of the package in the dir,
+// from the module path of the enclosing go.mod, the dir needs not exist.
+func ModulePath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("get absolute path of %s failed: %w", dir, err)
+	}
+
+	root := findModuleRoot(dir)
+	if root == "" {
+		return "", fmt.Errorf("go.mod not found in %s or its parents", dir)
+	}
+
+	b, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("read go.mod failed: %w", err)
+	}
+
+	module := modfile.ModulePath(b)
+	if module == "" {
+		return "", fmt.Errorf("module path not found in %s", filepath.Join(root, "go.mod"))
+	}
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", fmt.Errorf("get %s path relative to the module failed: %w", dir, err)
+	}
+
+	return path.Join(module, filepath.ToSlash(rel)), nil
+}
+
+// findModuleRoot returns the dir of the go.mod enclosing dir, or an empty string when there is none.
+func findModuleRoot(dir string) string {
+	dir = filepath.Clean(dir)
+
+	for {
+		if fi, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil && !fi.IsDir() {
+			return dir
+		}
+
+		d := filepath.Dir(dir)
+
+		if d == dir {
+			return ""
+		}
+
+		dir = d
+	}
+}
@@ -0,0 +1,28 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModulePath(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/acme/app\n"), 0600))
+
+	module, err := ModulePath(filepath.Join(dir, "internal", "entity"))
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/acme/app/internal/entity", module)
+
+	module, err = ModulePath(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/acme/app", module)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("go 1.16\n"), 0600))
+
+	_, err = ModulePath(dir)
+	assert.EqualError(t, err, "module path not found in "+filepath.Join(dir, "go.mod"))
+}
@@ -0,0 +1,67 @@
+package builder
+
+import (
+	"io/fs"
+)
+
+// Option configures the Builder.
+type Option func(*Builder)
+
+// WithOutput writes the generated files in the dir of the disk,
+// the module path is resolved from the go.mod enclosing the dir unless set with WithModulePath.
+func WithOutput(dir string) Option {
+	return func(b *Builder) {
+		b.dir = dir
+		b.fs = DirFS(dir)
+	}
+}
+
+// WithFS writes the generated files in fsys.
+func WithFS(fsys FS) Option {
+	return func(b *Builder) {
+		b.fs = fsys
+	}
+}
+
+// WithModulePath sets the import path of the generated package, e.g. github.com/acme/app/entity.
+func WithModulePath(module string) Option {
+	return func(b *Builder) {
+		b.modulePath = module
+	}
+}
+
+// WithPackage sets the name of the generated package, default to entity.
+func WithPackage(name string) Option {
+	return func(b *Builder) {
+		b.data.Package = name
+	}
+}
+
+// WithConfig sets the conventions used to generate the entities.
+func WithConfig(config Config) Option {
+	return func(b *Builder) {
+		b.config = config
+	}
+}
+
+// WithTemplates adds templates dirs overriding the embedded templates with the same path
+// or rendered as extra files, they override the templates of the extensions too.
+func WithTemplates(fss ...fs.FS) Option {
+	return func(b *Builder) {
+		b.templates = append(b.templates, fss...)
+	}
+}
+
+// WithWorkers sets the number of templates rendered concurrently, default to GOMAXPROCS.
+func WithWorkers(n int) Option {
+	return func(b *Builder) {
+		b.workers = n
+	}
+}
+
+// WithExtensions adds extensions, their hooks are called in order.
+func WithExtensions(extensions ...Extension) Option {
+	return func(b *Builder) {
+		b.extensions = append(b.extensions, extensions...)
+	}
+}
@@ -0,0 +1,65 @@
+package builder
+
+import (
+	"sort"
+
+	"ariga.io/atlas/sql/schema"
+)
+
+// sortedTables returns the tables sorted by name, the entities are generated in a stable order
+// whatever the order of the spec files or of the inspected database.
+func sortedTables(tables []*schema.Table) []*schema.Table {
+	sorted := append([]*schema.Table(nil), tables...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	return sorted
+}
+
+// orderedColumns returns the columns of the table in the column order of the config.
+func (b *Builder) orderedColumns(t *schema.Table) []*schema.Column {
+	columns := append([]*schema.Column(nil), t.Columns...)
+
+	if b.config.ColumnOrder != ColumnOrderName {
+		return columns
+	}
+
+	// position of the column in the primary key, the other columns are after.
+	position := map[string]int{}
+
+	if t.PrimaryKey != nil {
+		for i, part := range t.PrimaryKey.Parts {
+			if part.C != nil {
+				position[part.C.Name] = i
+			}
+		}
+	}
+
+	rank := func(c *schema.Column) int {
+		if i, ok := position[c.Name]; ok {
+			return i
+		}
+
+		return len(position)
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		ri, rj := rank(columns[i]), rank(columns[j])
+		if ri != rj {
+			return ri < rj
+		}
+
+		return columns[i].Name < columns[j].Name
+	})
+
+	return columns
+}
+
+// sortImports sorts the import lists in place.
+func sortImports(imports ...[]string) {
+	for _, list := range imports {
+		sort.Strings(list)
+	}
+}
@@ -0,0 +1,220 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/euskadi31/entify/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// TypeOverride replaces the Go type generated for a column,
+// it matches a column by its name ("table.column") or by its SQL type ("char(36)" or "decimal").
+// The conversions are ignored for JSON columns, the value is (un)marshaled with encoding/json.
+type TypeOverride struct {
+	// Column is the "table.column" name of the column to override.
+	Column string `yaml:"column" hcl:"column,optional"`
+
+	// SQLType is the SQL type of the columns to override, with or without size.
+	SQLType string `yaml:"sql_type" hcl:"sql_type,optional"`
+
+	// Type is the Go type of the field, e.g. uuid.UUID.
+	Type string `yaml:"type" hcl:"type,optional"`
+
+	// Package is the import path of the Go type.
+	Package string `yaml:"package" hcl:"package,optional"`
+
+	// ScanType is the type the column is scanned into, default to the one of the SQL type.
+	// It must be declared in database/sql or in Package, e.g. sql.NullString or uuid.NullUUID.
+	ScanType string `yaml:"scan_type" hcl:"scan_type,optional"`
+
+	// ScanValue is the format of the expression converting the scanned value to Type, default to Type(%s).
+	ScanValue string `yaml:"scan_value" hcl:"scan_value,optional"`
+
+	// SQLValue is the format of the expression converting a Type value to a driver value, if any.
+	SQLValue string `yaml:"sql_value" hcl:"sql_value,optional"`
+}
+
+// TypeOverrides is a list of type overrides.
+type TypeOverrides []*TypeOverride
+
+// Lookup returns the override of the column, column overrides take precedence
+// over SQL type overrides and a sized SQL type over an unsized one.
+func (o TypeOverrides) Lookup(table string, col *schema.Column) *TypeOverride {
+	var byName, byFullType *TypeOverride
+
+	name, fullName := SQLTypeName(col.Type.Type)
+
+	for _, override := range o {
+		switch {
+		case override.Column != "":
+			if override.Column == table+"."+col.Name {
+				return override
+			}
+		case override.SQLType == "":
+		case normalizeSQLType(override.SQLType) == fullName:
+			byFullType = override
+		case normalizeSQLType(override.SQLType) == name && byName == nil:
+			byName = override
+		}
+	}
+
+	if byFullType != nil {
+		return byFullType
+	}
+
+	return byName
+}
+
+// Apply overrides the column type. The kind of the type is kept while the Go type is unchanged
+// or of the same kind, e.g. int64 for an int column, so the timestamp and version conventions still apply.
+// The conversions of the SQL type are kept while the Go type is unchanged and the override has none.
+// A JSON column keeps its kind, the value is unmarshaled on scan and marshaled on write.
+// A Go type of another kind than the column, e.g. uuid.UUID for a char column, requires scan_type or scan_value,
+// the scanned value is not convertible to it.
+func (o *TypeOverride) Apply(ct *ColumnType) error {
+	sameType := o.Type == ct.Type
+
+	if !sameType && ct.TypeKind != types.FieldTypeKindJson && o.ScanType == "" && o.ScanValue == "" && goTypeKind(o.Type) != ct.TypeKind {
+		return fmt.Errorf("type %s is not convertible from %s, set scan_type or scan_value", o.Type, ct.NullableSQLType)
+	}
+
+	ct.Type = o.Type
+	ct.EnumValues = nil
+	ct.DefaultValue = "*new(" + o.Type + ")"
+
+	if o.Package != "" || !sameType {
+		ct.Package = o.Package
+	}
+
+	if ct.TypeKind == types.FieldTypeKindJson {
+		ct.JSON = true
+		ct.ScanValue = ""
+		ct.SQLValue = "internal.JSONValue{V: %s}"
+		ct.SQLPackage = ""
+
+		return nil
+	}
+
+	if !sameType {
+		ct.TypeKind = goTypeKind(o.Type)
+	}
+
+	if !sameType || o.ScanValue != "" || o.SQLValue != "" {
+		ct.ScanValue = o.ScanValue
+		ct.SQLValue = o.SQLValue
+		ct.SQLPackage = ""
+	}
+
+	if o.ScanType != "" {
+		ct.NullableSQLType = o.ScanType
+		ct.NullableSQLAccessValue = scanTypeAccessValue(o.ScanType)
+	}
+
+	return nil
+}
+
+// goTypeKind returns the kind of a builtin Go type, unknown for the other types.
+func goTypeKind(goType string) types.FieldTypeKind {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return types.FieldTypeKindNumber
+	case "string":
+		return types.FieldTypeKindString
+	case "bool":
+		return types.FieldTypeKindBool
+	case "time.Time":
+		return types.FieldTypeKindDate
+	case "[]byte":
+		return types.FieldTypeKindBytes
+	}
+
+	return types.FieldTypeKindUnknown
+}
+
+// Validate checks the override matches a column or a SQL type and has a Go type.
+func (o *TypeOverride) Validate() error {
+	if o.Column == "" && o.SQLType == "" {
+		return fmt.Errorf("column or sql_type is required")
+	}
+
+	if o.Column != "" && !strings.Contains(o.Column, ".") {
+		return fmt.Errorf("column %s must be formatted as table.column", o.Column)
+	}
+
+	if o.Type == "" {
+		return fmt.Errorf("type is required")
+	}
+
+	return nil
+}
+
+// LoadTypeOverrides reads the type overrides from a YAML file.
+func LoadTypeOverrides(filename string) (TypeOverrides, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read %s file failed: %w", filename, err)
+	}
+
+	file := struct {
+		Types TypeOverrides `yaml:"types"`
+	}{}
+
+	if err := yaml.Unmarshal(b, &file); err != nil {
+		return nil, fmt.Errorf("unmarshal %s file failed: %w", filename, err)
+	}
+
+	for i, override := range file.Types {
+		if err := override.Validate(); err != nil {
+			return nil, fmt.Errorf("type override #%d: %w", i, err)
+		}
+	}
+
+	return file.Types, nil
+}
+
+// SQLTypeName returns the name of the SQL type without and with its size,
+// e.g. "char" and "char(36)".
+func SQLTypeName(t schema.Type) (string, string) {
+	name := ""
+
+	if v := reflect.Indirect(reflect.ValueOf(t)); v.Kind() == reflect.Struct {
+		if f := v.FieldByName("T"); f.IsValid() && f.Kind() == reflect.String {
+			name = normalizeSQLType(f.String())
+		}
+	}
+
+	switch t := t.(type) {
+	case *schema.StringType:
+		if t.Size > 0 {
+			return name, name + "(" + strconv.Itoa(t.Size) + ")"
+		}
+	case *schema.BinaryType:
+		if t.Size != nil {
+			return name, name + "(" + strconv.Itoa(*t.Size) + ")"
+		}
+	case *schema.DecimalType:
+		if t.Precision > 0 {
+			return name, name + "(" + strconv.Itoa(t.Precision) + "," + strconv.Itoa(t.Scale) + ")"
+		}
+	}
+
+	return name, name
+}
+
+func normalizeSQLType(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, " ", ""))
+}
+
+// sql.NullString => String, uuid.NullUUID => UUID, sql.Null[T] => V.
+func scanTypeAccessValue(scanType string) string {
+	if strings.HasSuffix(scanType, "]") {
+		return "V"
+	}
+
+	return strings.TrimPrefix(scanType[strings.LastIndex(scanType, ".")+1:], "Null")
+}
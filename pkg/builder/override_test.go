@@ -0,0 +1,138 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/euskadi31/entify/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTypeOverrides(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "types.yaml")
+
+	assert.NoError(t, os.WriteFile(filename, []byte(`types:
+  - column: users.id
+    type: uuid.UUID
+    package: github.com/google/uuid
+    scan_value: uuid.MustParse(%s)
+    sql_value: "%s.String()"
+  - sql_type: decimal
+    type: money.Money
+    package: example.com/money
+`), 0600))
+
+	overrides, err := LoadTypeOverrides(filename)
+	assert.NoError(t, err)
+	assert.Len(t, overrides, 2)
+	assert.Equal(t, "users.id", overrides[0].Column)
+	assert.Equal(t, "uuid.MustParse(%s)", overrides[0].ScanValue)
+	assert.Equal(t, "decimal", overrides[1].SQLType)
+
+	assert.NoError(t, os.WriteFile(filename, []byte(`types:
+  - column: id
+    type: uuid.UUID
+`), 0600))
+
+	_, err = LoadTypeOverrides(filename)
+	assert.EqualError(t, err, "type override #0: column id must be formatted as table.column")
+}
+
+func TestTypeOverridesLookup(t *testing.T) {
+	overrides := TypeOverrides{
+		{SQLType: "char", Type: "Char"},
+		{SQLType: "CHAR(36)", Type: "uuid.UUID"},
+		{Column: "users.code", Type: "Code"},
+	}
+
+	assert.Equal(t, "uuid.UUID", overrides.Lookup("users", schema.NewStringColumn("id", "char", schema.StringSize(36))).Type)
+	assert.Equal(t, "Char", overrides.Lookup("users", schema.NewStringColumn("name", "char", schema.StringSize(10))).Type)
+	assert.Equal(t, "Code", overrides.Lookup("users", schema.NewStringColumn("code", "char", schema.StringSize(36))).Type)
+	assert.Nil(t, overrides.Lookup("users", schema.NewStringColumn("email", "varchar", schema.StringSize(36))))
+}
+
+func TestTypeOverrideApply(t *testing.T) {
+	ct := newStringColumnType()
+
+	assert.NoError(t, (&TypeOverride{
+		Type:      "uuid.UUID",
+		Package:   "github.com/google/uuid",
+		ScanType:  "uuid.NullUUID",
+		ScanValue: "%s",
+	}).Apply(ct))
+
+	assert.Equal(t, "uuid.UUID", ct.Type)
+	assert.Equal(t, types.FieldTypeKindUnknown, ct.TypeKind)
+	assert.Equal(t, "github.com/google/uuid", ct.Package)
+	assert.Equal(t, "uuid.NullUUID", ct.NullableSQLType)
+	assert.Equal(t, "UUID", ct.NullableSQLAccessValue)
+	assert.Equal(t, "*new(uuid.UUID)", ct.DefaultValue)
+}
+
+func TestTypeOverrideApplyKeepKind(t *testing.T) {
+	ct, err := ColumnTypeToType(&schema.ColumnType{Type: &schema.IntegerType{T: "int"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, (&TypeOverride{
+		Type: "int64",
+	}).Apply(ct))
+
+	assert.Equal(t, "int64", ct.Type)
+	assert.Equal(t, types.FieldTypeKindNumber, ct.TypeKind)
+
+	ct, err = ColumnTypeToType(&schema.ColumnType{Type: &schema.TimeType{T: "timestamp"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, (&TypeOverride{
+		Type:     "time.Time",
+		ScanType: "sql.NullTime",
+	}).Apply(ct))
+
+	assert.Equal(t, types.FieldTypeKindDate, ct.TypeKind)
+	assert.Equal(t, "time", ct.Package)
+	assert.Equal(t, "sql.NullTime", ct.NullableSQLType)
+}
+
+func TestTypeOverrideApplyJSON(t *testing.T) {
+	ct, err := ColumnTypeToType(&schema.ColumnType{Type: &schema.JSONType{T: "json"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, (&TypeOverride{
+		Type:      "map[string]any",
+		ScanValue: "%s",
+	}).Apply(ct))
+
+	assert.Equal(t, "map[string]any", ct.Type)
+	assert.Equal(t, types.FieldTypeKindJson, ct.TypeKind)
+	assert.True(t, ct.JSON)
+	assert.Equal(t, "", ct.ScanValue)
+	assert.Equal(t, "internal.JSONValue{V: %s}", ct.SQLValue)
+	assert.Equal(t, "sql.NullString", ct.NullableSQLType)
+}
+
+func TestTypeOverrideApplyNotConvertible(t *testing.T) {
+	ct := newStringColumnType()
+
+	err := (&TypeOverride{
+		Type:    "uuid.UUID",
+		Package: "github.com/google/uuid",
+	}).Apply(ct)
+	assert.EqualError(t, err, "type uuid.UUID is not convertible from sql.NullString, set scan_type or scan_value")
+
+	ct, err = ColumnTypeToType(&schema.ColumnType{Type: &schema.StringType{T: "varchar"}})
+	assert.NoError(t, err)
+
+	err = (&TypeOverride{
+		Type: "int64",
+	}).Apply(ct)
+	assert.EqualError(t, err, "type int64 is not convertible from sql.NullString, set scan_type or scan_value")
+
+	ct, err = ColumnTypeToType(&schema.ColumnType{Type: &schema.StringType{T: "varchar"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, (&TypeOverride{
+		Type: "string",
+	}).Apply(ct))
+}
@@ -2,20 +2,22 @@
 package builder
 
 import (
+	"fmt"
 	"go/token"
-	"os"
-	"path"
-	"path/filepath"
+	"strconv"
 	"strings"
 
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/postgres"
 	"ariga.io/atlas/sql/schema"
-	"github.com/davecgh/go-spew/spew"
 	"github.com/euskadi31/entify/pkg/types"
 	"github.com/iancoleman/strcase"
 )
 
 const (
 	pkgDatabaseSQL = "database/sql"
+	pkgUUID        = "github.com/google/uuid"
+	pkgPQ          = "github.com/lib/pq"
 )
 
 func TableNameToReceiver(name string) string {
@@ -58,11 +60,54 @@ func TableNameToPackageName(name string) string {
 	return strings.Join(parts, "")
 }
 
+// SchemaNameToPackageName returns a valid Go package name for the schema, e.g. "billing-v2" => billingv2.
+func SchemaNameToPackageName(name string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9' && b.Len() > 0) {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// initialisms maps the words written in upper case in the Go names to their upper case form, e.g. uuid => UUID.
+type initialisms map[string]string
+
+// newInitialisms returns the default initialisms (id, url and uri) extended with the words.
+func newInitialisms(words ...string) initialisms {
+	in := initialisms{}
+
+	for k, v := range signMap {
+		in[k] = v
+	}
+
+	for _, word := range words {
+		in[strings.ToLower(word)] = strings.ToUpper(word)
+	}
+
+	return in
+}
+
 func ColumnNameToPropertyName(name string) string {
+	return initialisms(signMap).propertyName(name)
+}
+
+func TableNameToVariableName(name string) string {
+	return initialisms(signMap).tableVariableName(name)
+}
+
+func ColumnNameToVariableName(name string) string {
+	return initialisms(signMap).columnVariableName(name)
+}
+
+func (in initialisms) propertyName(name string) string {
 	parts := strings.Split(name, "_")
 
 	for i, part := range parts {
-		if v, ok := signMap[strings.ToLower(part)]; ok {
+		if v, ok := in[strings.ToLower(part)]; ok {
 			parts[i] = v
 		} else {
 			parts[i] = strcase.ToCamel(part)
@@ -72,11 +117,11 @@ func ColumnNameToPropertyName(name string) string {
 	return strings.Join(parts, "")
 }
 
-func TableNameToVariableName(name string) string {
+func (in initialisms) tableVariableName(name string) string {
 	parts := strings.Split(name, "_")
 
 	for i, part := range parts {
-		if v, ok := signMap[strings.ToLower(part)]; ok && i > 0 {
+		if v, ok := in[strings.ToLower(part)]; ok && i > 0 {
 			parts[i] = v
 		} else {
 			parts[i] = strcase.ToCamel(part)
@@ -90,11 +135,11 @@ func TableNameToVariableName(name string) string {
 	return strings.Join(parts, "")
 }
 
-func ColumnNameToVariableName(name string) string {
+func (in initialisms) columnVariableName(name string) string {
 	parts := strings.Split(name, "_")
 
 	for i, part := range parts {
-		if v, ok := signMap[strings.ToLower(part)]; ok && i > 0 {
+		if v, ok := in[strings.ToLower(part)]; ok && i > 0 {
 			parts[i] = v
 		} else {
 			parts[i] = strcase.ToCamel(part)
@@ -154,7 +199,10 @@ func ColumnTypeToSQLType(colType *schema.ColumnType) (string, string, string) {
 		}
 
 		if colType.Null {
-			if v, ok := typeNullableIntMap[t.T]; ok {
+			if t.Unsigned && goType != "" {
+				sqlType = "sql.Null[" + goType + "]"
+				goPkg = pkgDatabaseSQL
+			} else if v, ok := typeNullableIntMap[t.T]; ok {
 				sqlType = v
 				goPkg = pkgDatabaseSQL
 			}
@@ -192,6 +240,7 @@ func ColumnTypeToSQLType(colType *schema.ColumnType) (string, string, string) {
 
 type ColumnType struct {
 	Type                   string
+	EnumValues             []string
 	TypeKind               types.FieldTypeKind
 	SQLType                string
 	DefaultValue           string
@@ -199,20 +248,26 @@ type ColumnType struct {
 	Package                string
 	NullablePackage        string
 	NullableSQLAccessValue string
+	// ScanValue is the format of the expression converting the scanned value to Type, default to Type(%s).
+	ScanValue string
+	// SQLValue is the format of the expression converting a Type value to a driver value, if any.
+	SQLValue string
+	// SQLPackage is the package required by the ScanValue and SQLValue expressions.
+	SQLPackage string
+	// JSON reports if Type is unmarshaled from and marshaled to a JSON column.
+	JSON bool
 }
 
-func ColumnTypeToType(colType *schema.ColumnType) *ColumnType {
+// ColumnTypeToType returns the Go type of the column type,
+// an error is returned if the column type is not supported.
+func ColumnTypeToType(colType *schema.ColumnType) (*ColumnType, error) {
+	if colType == nil || colType.Type == nil {
+		return nil, fmt.Errorf("column type is not defined")
+	}
+
 	switch t := colType.Type.(type) {
 	case *schema.StringType:
-		return &ColumnType{
-			Type:                   "string",
-			TypeKind:               types.FieldTypeKindString,
-			SQLType:                "string",
-			DefaultValue:           `""`,
-			NullableSQLType:        "sql.NullString",
-			NullablePackage:        pkgDatabaseSQL,
-			NullableSQLAccessValue: "String",
-		}
+		return newStringColumnType(), nil
 	case *schema.BoolType:
 		return &ColumnType{
 			Type:                   "bool",
@@ -222,33 +277,13 @@ func ColumnTypeToType(colType *schema.ColumnType) *ColumnType {
 			NullableSQLType:        "sql.NullBool",
 			NullablePackage:        pkgDatabaseSQL,
 			NullableSQLAccessValue: "Bool",
-		}
+		}, nil
 	case *schema.IntegerType:
-		ct := &ColumnType{
-			TypeKind:        types.FieldTypeKindNumber,
-			DefaultValue:    `0`,
-			NullablePackage: pkgDatabaseSQL,
-		}
-
-		if t.Unsigned {
-			if v, ok := typeUintMap[t.T]; ok {
-				ct.Type = v
-				ct.SQLType = v
-			}
-		} else {
-			if v, ok := typeIntMap[t.T]; ok {
-				ct.Type = v
-				ct.SQLType = v
-			}
-		}
-
-		if v, ok := typeNullableIntMap[t.T]; ok {
-			ct.NullableSQLType = v
-			ct.NullableSQLAccessValue = strings.Replace(v, "sql.Null", "", 1)
-		}
-
-		return ct
-
+		return newIntegerColumnType(t.T, t.Unsigned), nil
+	case *postgres.SerialType:
+		return newIntegerColumnType(strings.Replace(t.T, "serial", "int", 1), false), nil
+	case *postgres.OIDType:
+		return newIntegerColumnType("oid", true), nil
 	case *schema.FloatType:
 		return &ColumnType{
 			Type:                   "float64",
@@ -258,8 +293,10 @@ func ColumnTypeToType(colType *schema.ColumnType) *ColumnType {
 			NullableSQLType:        "sql.NullFloat64",
 			NullablePackage:        pkgDatabaseSQL,
 			NullableSQLAccessValue: "Float64",
-		}
-
+		}, nil
+	case *schema.DecimalType, *postgres.CurrencyType:
+		// exact numeric values are kept as string to avoid losing precision.
+		return newStringColumnType(), nil
 	case *schema.TimeType:
 		return &ColumnType{
 			Type:                   "time.Time",
@@ -270,7 +307,7 @@ func ColumnTypeToType(colType *schema.ColumnType) *ColumnType {
 			Package:                "time",
 			NullablePackage:        pkgDatabaseSQL,
 			NullableSQLAccessValue: "Time",
-		}
+		}, nil
 	case *schema.JSONType:
 		return &ColumnType{
 			Type:                   "json.RawMessage",
@@ -281,77 +318,257 @@ func ColumnTypeToType(colType *schema.ColumnType) *ColumnType {
 			Package:                "encoding/json",
 			NullablePackage:        pkgDatabaseSQL,
 			NullableSQLAccessValue: "String",
+		}, nil
+	case *schema.EnumType:
+		ct := newStringColumnType()
+		ct.EnumValues = t.Values
+
+		return ct, nil
+	case *schema.BinaryType, *schema.SpatialType, *mysql.BitType, *postgres.BitType:
+		return &ColumnType{
+			Type:                   "[]byte",
+			TypeKind:               types.FieldTypeKindBytes,
+			SQLType:                "[]byte",
+			DefaultValue:           `nil`,
+			NullableSQLType:        "sql.Null[[]byte]",
+			NullablePackage:        pkgDatabaseSQL,
+			NullableSQLAccessValue: "V",
+		}, nil
+	case *schema.UUIDType:
+		return &ColumnType{
+			Type:                   "uuid.UUID",
+			TypeKind:               types.FieldTypeKindUUID,
+			SQLType:                "uuid.UUID",
+			DefaultValue:           `uuid.UUID{}`,
+			NullableSQLType:        "uuid.NullUUID",
+			Package:                pkgUUID,
+			NullablePackage:        pkgUUID,
+			NullableSQLAccessValue: "UUID",
+		}, nil
+	case *mysql.NetworkType:
+		return newIPColumnType(), nil
+	case *postgres.NetworkType:
+		if t.T == "inet" {
+			return newIPColumnType(), nil
 		}
-	default:
-		spew.Dump(t)
+
+		// cidr and mac addresses
+		return newStringColumnType(), nil
+	case *mysql.SetType, *postgres.IntervalType, *postgres.RangeType, *postgres.TextSearchType, *postgres.XMLType:
+		return newStringColumnType(), nil
+	case *postgres.ArrayType:
+		return newArrayColumnType(t)
+	case *postgres.DomainType:
+		if t.Type == nil {
+			return nil, fmt.Errorf("domain type %s has no underlying type", t.T)
+		}
+
+		return ColumnTypeToType(&schema.ColumnType{
+			Type: t.Type,
+			Null: colType.Null,
+		})
+	case *schema.UnsupportedType:
+		return nil, fmt.Errorf("column type %s is not supported", t.T)
 	}
 
-	return nil
+	return nil, fmt.Errorf("column type %T (%s) is not supported", colType.Type, colType.Raw)
+}
+
+func newStringColumnType() *ColumnType {
+	return &ColumnType{
+		Type:                   "string",
+		TypeKind:               types.FieldTypeKindString,
+		SQLType:                "string",
+		DefaultValue:           `""`,
+		NullableSQLType:        "sql.NullString",
+		NullablePackage:        pkgDatabaseSQL,
+		NullableSQLAccessValue: "String",
+	}
 }
 
-func findModuleRoot(dir string) (root string) {
-	if dir == "" {
-		panic("dir not set")
+func newIntegerColumnType(name string, unsigned bool) *ColumnType {
+	ct := &ColumnType{
+		TypeKind:        types.FieldTypeKindNumber,
+		DefaultValue:    `0`,
+		NullablePackage: pkgDatabaseSQL,
 	}
 
-	dir = filepath.Clean(dir)
+	if unsigned {
+		ct.Type = "uint64"
 
-	// Look for enclosing go.mod.
-	for {
-		if fi, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil && !fi.IsDir() {
-			return dir
+		if v, ok := typeUintMap[name]; ok {
+			ct.Type = v
 		}
+	} else {
+		ct.Type = "int64"
 
-		d := filepath.Dir(dir)
-
-		if d == dir {
-			break
+		if v, ok := typeIntMap[name]; ok {
+			ct.Type = v
 		}
+	}
+
+	ct.SQLType = ct.Type
+
+	// unsigned values are scanned as is, the signed sql.NullInt* would overflow above their range.
+	if unsigned {
+		ct.NullableSQLType = "sql.Null[" + ct.Type + "]"
+		ct.NullableSQLAccessValue = "V"
+		ct.ScanValue = "%s"
+
+		return ct
+	}
+
+	ct.NullableSQLType = "sql.NullInt64"
 
-		dir = d
+	if v, ok := typeNullableIntMap[name]; ok {
+		ct.NullableSQLType = v
 	}
 
-	return ""
+	ct.NullableSQLAccessValue = strings.Replace(ct.NullableSQLType, "sql.Null", "", 1)
+
+	return ct
 }
 
-func reverseSlice(s []string) []string {
-	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
-		s[i], s[j] = s[j], s[i]
+func newIPColumnType() *ColumnType {
+	return &ColumnType{
+		Type:                   "net.IP",
+		TypeKind:               types.FieldTypeKindNetwork,
+		SQLType:                "string",
+		DefaultValue:           `nil`,
+		NullableSQLType:        "sql.NullString",
+		Package:                "net",
+		NullablePackage:        pkgDatabaseSQL,
+		NullableSQLAccessValue: "String",
+		ScanValue:              "net.ParseIP(%s)",
+		SQLValue:               "%s.String()",
 	}
+}
 
-	return s
+func newArrayColumnType(t *postgres.ArrayType) (*ColumnType, error) {
+	var elem, array string
+
+	switch t.Type.(type) {
+	case *schema.StringType, *schema.EnumType, *schema.DecimalType, *schema.UUIDType:
+		elem, array = "string", "pq.StringArray"
+	case *schema.IntegerType, *postgres.SerialType:
+		elem, array = "int64", "pq.Int64Array"
+	case *schema.FloatType:
+		elem, array = "float64", "pq.Float64Array"
+	case *schema.BoolType:
+		elem, array = "bool", "pq.BoolArray"
+	case *schema.BinaryType:
+		elem, array = "[]byte", "pq.ByteaArray"
+	default:
+		return nil, fmt.Errorf("array type %s is not supported", t.T)
+	}
+
+	return &ColumnType{
+		Type:                   "[]" + elem,
+		TypeKind:               types.FieldTypeKindArray,
+		SQLType:                array,
+		DefaultValue:           `nil`,
+		NullableSQLType:        "sql.Null[" + array + "]",
+		NullablePackage:        pkgDatabaseSQL,
+		NullableSQLAccessValue: "V",
+		SQLValue:               array + "(%s)",
+		SQLPackage:             pkgPQ,
+	}, nil
 }
 
-func findCurrentModulePath(dir string) (root string) {
-	if dir == "" {
-		panic("dir not set")
+// EnumValueToConstName returns the name of the constant of an enum value,
+// e.g. Status and "in_progress" => StatusInProgress.
+func EnumValueToConstName(typeName string, value string) string {
+	name := strcase.ToCamel(value)
+
+	if name == "" {
+		name = "Empty"
 	}
 
-	dir = filepath.Clean(dir)
+	return typeName + name
+}
 
-	parts := []string{}
+// ColumnDefault is the Go representation of a column default value.
+type ColumnDefault struct {
+	Value   string
+	Func    bool
+	Package string
+}
 
-	// Look for enclosing go.mod.
-	for {
-		if fi, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil && !fi.IsDir() {
-			return path.Join(reverseSlice(parts)...)
-		}
+// ColumnDefaultToValue converts the default value of the column to a Go expression, the enum constants
+// are named after the property name of the column, it returns nil if the column has no default
+// or if the default can't be expressed in Go.
+func ColumnDefaultToValue(col *schema.Column, ct *ColumnType, propertyName string) *ColumnDefault {
+	if ct == nil {
+		return nil
+	}
 
-		d := filepath.Dir(dir)
+	switch d := schema.UnderlyingExpr(col.Default).(type) {
+	case *schema.Literal:
+		v := unquote(d.V)
+
+		switch ct.TypeKind {
+		case types.FieldTypeKindBool:
+			switch strings.ToLower(v) {
+			case "0", "false":
+				return &ColumnDefault{Value: "false"}
+			case "1", "true":
+				return &ColumnDefault{Value: "true"}
+			}
+		case types.FieldTypeKindNumber:
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				return &ColumnDefault{Value: v}
+			}
+		case types.FieldTypeKindString:
+			if len(ct.EnumValues) > 0 {
+				for _, ev := range ct.EnumValues {
+					if ev == v {
+						return &ColumnDefault{Value: EnumValueToConstName(propertyName, v)}
+					}
+				}
+
+				return nil
+			}
 
-		if d == dir {
-			break
+			return &ColumnDefault{Value: strconv.Quote(v)}
+		}
+	case *schema.RawExpr:
+		if ct.TypeKind == types.FieldTypeKindDate && isCurrentTimestamp(d.X) {
+			return &ColumnDefault{
+				Value:   "time.Now",
+				Func:    true,
+				Package: "time",
+			}
 		}
+	}
+
+	return nil
+}
+
+func isCurrentTimestamp(expr string) bool {
+	expr = strings.ToLower(strings.TrimSpace(expr))
 
-		parts = append(parts, path.Base(dir))
+	if i := strings.Index(expr, "("); i > 0 {
+		expr = expr[0:i]
+	}
 
-		dir = d
+	switch expr {
+	case "current_timestamp", "now", "localtimestamp", "localtime":
+		return true
 	}
 
-	return path.Join(reverseSlice(parts)...)
+	return false
 }
 
-//nolint:wrapcheck
-func readModFile(dir string) ([]byte, error) {
-	return os.ReadFile(path.Join(dir, "go.mod"))
+func unquote(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+
+	if (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		q := string(s[0])
+
+		return strings.ReplaceAll(s[1:len(s)-1], q+q, q)
+	}
+
+	return s
 }
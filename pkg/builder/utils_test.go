@@ -3,6 +3,8 @@ package builder
 import (
 	"testing"
 
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,3 +25,148 @@ func TestColumnNameToPropertyName(t *testing.T) {
 		assert.Equal(t, item.expected, ColumnNameToPropertyName(item.value))
 	}
 }
+
+func TestColumnDefaultToValue(t *testing.T) {
+	for _, item := range []struct {
+		column   *schema.Column
+		expected *ColumnDefault
+	}{
+		{
+			column:   schema.NewBoolColumn("enabled", "bool").SetDefault(&schema.Literal{V: "0"}),
+			expected: &ColumnDefault{Value: "false"},
+		},
+		{
+			column:   schema.NewIntColumn("status", "tinyint").SetDefault(&schema.Literal{V: "2"}),
+			expected: &ColumnDefault{Value: "2"},
+		},
+		{
+			column:   schema.NewStringColumn("locale", "varchar").SetDefault(&schema.Literal{V: "'fr_FR'"}),
+			expected: &ColumnDefault{Value: `"fr_FR"`},
+		},
+		{
+			column:   schema.NewTimeColumn("created_at", "timestamp").SetDefault(&schema.RawExpr{X: "CURRENT_TIMESTAMP"}),
+			expected: &ColumnDefault{Value: "time.Now", Func: true, Package: "time"},
+		},
+		{
+			column:   schema.NewStringColumn("id", "char").SetDefault(&schema.RawExpr{X: "uuid()"}),
+			expected: nil,
+		},
+		{
+			column:   schema.NewEnumColumn("status", schema.EnumValues("draft", "published")).SetDefault(&schema.Literal{V: "draft"}),
+			expected: &ColumnDefault{Value: "StatusDraft"},
+		},
+		{
+			column:   schema.NewEnumColumn("status", schema.EnumValues("draft", "published")).SetDefault(&schema.Literal{V: "deleted"}),
+			expected: nil,
+		},
+		{
+			column:   schema.NewStringColumn("email", "varchar"),
+			expected: nil,
+		},
+	} {
+		ct, err := ColumnTypeToType(item.column.Type)
+		assert.NoError(t, err)
+
+		assert.Equal(t, item.expected, ColumnDefaultToValue(item.column, ct, ColumnNameToPropertyName(item.column.Name)))
+	}
+}
+
+func TestEnumValueToConstName(t *testing.T) {
+	for _, item := range []struct {
+		value    string
+		expected string
+	}{
+		{
+			value:    "active",
+			expected: "StatusActive",
+		},
+		{
+			value:    "in_progress",
+			expected: "StatusInProgress",
+		},
+		{
+			value:    "",
+			expected: "StatusEmpty",
+		},
+	} {
+		assert.Equal(t, item.expected, EnumValueToConstName("Status", item.value))
+	}
+}
+
+func TestColumnTypeToType(t *testing.T) {
+	for _, item := range []struct {
+		typ      schema.Type
+		expected string
+	}{
+		{
+			typ:      &schema.DecimalType{T: "decimal", Precision: 10, Scale: 2},
+			expected: "string",
+		},
+		{
+			typ:      &schema.BinaryType{T: "blob"},
+			expected: "[]byte",
+		},
+		{
+			typ:      &schema.UUIDType{T: "uuid"},
+			expected: "uuid.UUID",
+		},
+		{
+			typ:      &postgres.NetworkType{T: "inet"},
+			expected: "net.IP",
+		},
+		{
+			typ:      &postgres.ArrayType{Type: &schema.StringType{T: "text"}, T: "text[]"},
+			expected: "[]string",
+		},
+		{
+			typ:      &postgres.SerialType{T: "bigserial"},
+			expected: "int64",
+		},
+		{
+			typ:      &schema.IntegerType{T: "integer"},
+			expected: "int32",
+		},
+	} {
+		ct, err := ColumnTypeToType(&schema.ColumnType{Type: item.typ})
+		assert.NoError(t, err)
+		assert.Equal(t, item.expected, ct.Type)
+	}
+
+	_, err := ColumnTypeToType(&schema.ColumnType{Type: &schema.UnsupportedType{T: "geography"}})
+	assert.EqualError(t, err, "column type geography is not supported")
+}
+
+func TestColumnTypeToTypeUnsignedInteger(t *testing.T) {
+	ct, err := ColumnTypeToType(&schema.ColumnType{Type: &schema.IntegerType{T: "bigint", Unsigned: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, "uint64", ct.Type)
+	assert.Equal(t, "sql.Null[uint64]", ct.NullableSQLType)
+	assert.Equal(t, "V", ct.NullableSQLAccessValue)
+
+	ct, err = ColumnTypeToType(&schema.ColumnType{Type: &schema.IntegerType{T: "tinyint"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "int8", ct.Type)
+	assert.Equal(t, "sql.NullInt16", ct.NullableSQLType)
+	assert.Equal(t, "Int16", ct.NullableSQLAccessValue)
+}
+
+func TestTableNameToReceiver(t *testing.T) {
+	assert.Equal(t, "ua", TableNameToReceiver("users_activations"))
+	assert.Equal(t, "i", TableNameToReceiver("invoices"))
+	assert.Equal(t, "v", TableNameToReceiver("values"))
+}
+
+func TestSchemaNameToPackageName(t *testing.T) {
+	assert.Equal(t, "demo", SchemaNameToPackageName("demo"))
+	assert.Equal(t, "billingv2", SchemaNameToPackageName("Billing-v2"))
+	assert.Equal(t, "public", SchemaNameToPackageName("2public"))
+}
+
+func TestNewInitialisms(t *testing.T) {
+	names := newInitialisms("api")
+
+	assert.Equal(t, "APIKey", names.propertyName("api_key"))
+	assert.Equal(t, "ExternalAPIKey", names.propertyName("external_api_key"))
+	assert.Equal(t, "userAPIKey", names.columnVariableName("user_api_key"))
+	assert.Equal(t, "ApiKey", ColumnNameToPropertyName("api_key"))
+}
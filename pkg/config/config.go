@@ -0,0 +1,193 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/euskadi31/entify/pkg/builder"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"gopkg.in/yaml.v3"
+)
+
+// Filenames are the config files discovered in the working directory, by order of precedence.
+var Filenames = []string{"entify.yaml", "entify.yml", "entify.hcl"}
+
+// Config is the project configuration of entify, the relative paths are relative to the working directory.
+//
+//	output: ./entify/entity
+//	package: entity
+//	provider: mysql
+//	specs:
+//	  - schema/*.hcl
+//	templates: ./templates
+//	tables:
+//	  exclude:
+//	    - schema_migrations
+//	naming:
+//	  initialisms:
+//	    - api
+//	features:
+//	  optimistic_lock: false
+//	types:
+//	  - column: posts.tags
+//	    type: "[]string"
+type Config struct {
+	// Output is the directory of the generated code.
+	Output string `yaml:"output" hcl:"output,optional"`
+
+	// Package is the name of the generated package when the spec has a single schema.
+	Package string `yaml:"package" hcl:"package,optional"`
+
+	// Provider is the schema provider, detected from the spec when empty.
+	Provider string `yaml:"provider" hcl:"provider,optional"`
+
+	// Specs are the HCL spec files, directories or glob patterns used when none is given.
+	Specs []string `yaml:"specs" hcl:"specs,optional"`
+
+	// Templates is the dir of the templates overriding the embedded ones by path or rendered as extra files.
+	Templates string `yaml:"templates" hcl:"templates,optional"`
+
+	// Nullable is the Go representation of the nullable columns (value, pointer, sql).
+	Nullable string `yaml:"nullable" hcl:"nullable,optional"`
+
+	// ColumnOrder is the order of the fields of the entities (spec, name).
+	ColumnOrder string `yaml:"column_order" hcl:"column_order,optional"`
+
+	// Tables filters the generated tables.
+	Tables *Tables `yaml:"tables" hcl:"tables,block"`
+
+	// Naming holds the naming rules of the columns.
+	Naming *Naming `yaml:"naming" hcl:"naming,block"`
+
+	// Features enables or disables the generated features.
+	Features *Features `yaml:"features" hcl:"features,block"`
+
+	// Types replaces the Go type of the matching columns.
+	Types builder.TypeOverrides `yaml:"types" hcl:"type,block"`
+}
+
+// Tables holds the glob patterns of the included and excluded tables.
+type Tables struct {
+	Include []string `yaml:"include" hcl:"include,optional"`
+	Exclude []string `yaml:"exclude" hcl:"exclude,optional"`
+}
+
+// Naming holds the names of the convention columns and the extra initialisms.
+type Naming struct {
+	CreatedAt   *string  `yaml:"created_at" hcl:"created_at,optional"`
+	UpdatedAt   *string  `yaml:"updated_at" hcl:"updated_at,optional"`
+	DeletedAt   *string  `yaml:"deleted_at" hcl:"deleted_at,optional"`
+	Version     *string  `yaml:"version" hcl:"version,optional"`
+	Initialisms []string `yaml:"initialisms" hcl:"initialisms,optional"`
+}
+
+// Features enables or disables the features bound to the convention columns, all are enabled by default.
+type Features struct {
+	Timestamps     *bool `yaml:"timestamps" hcl:"timestamps,optional"`
+	SoftDelete     *bool `yaml:"soft_delete" hcl:"soft_delete,optional"`
+	OptimisticLock *bool `yaml:"optimistic_lock" hcl:"optimistic_lock,optional"`
+}
+
+// Find returns the config file of the dir, or an empty string when there is none.
+func Find(dir string) (string, error) {
+	for _, name := range Filenames {
+		filename := filepath.Join(dir, name)
+
+		if _, err := os.Stat(filename); err == nil {
+			return filename, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("stat %s failed: %w", filename, err)
+		}
+	}
+
+	return "", nil
+}
+
+// Load reads a YAML or HCL config file, depending on its extension.
+func Load(filename string) (*Config, error) {
+	cfg := &Config{}
+
+	switch filepath.Ext(filename) {
+	case ".yaml", ".yml":
+		b, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("read %s file failed: %w", filename, err)
+		}
+
+		if err := yaml.Unmarshal(b, cfg); err != nil {
+			return nil, fmt.Errorf("unmarshal %s file failed: %w", filename, err)
+		}
+	case ".hcl":
+		if err := hclsimple.DecodeFile(filename, nil, cfg); err != nil {
+			return nil, fmt.Errorf("decode %s file failed: %w", filename, err)
+		}
+	default:
+		return nil, fmt.Errorf("config file %s must be a yaml or hcl file", filename)
+	}
+
+	for i, override := range cfg.Types {
+		if err := override.Validate(); err != nil {
+			return nil, fmt.Errorf("type override #%d: %w", i, err)
+		}
+	}
+
+	return cfg, nil
+}
+
+// Apply sets the settings of the file on the builder config.
+func (c *Config) Apply(config *builder.Config) {
+	if c.Nullable != "" {
+		config.NullableMode = builder.NullableMode(c.Nullable)
+	}
+
+	if c.ColumnOrder != "" {
+		config.ColumnOrder = builder.ColumnOrder(c.ColumnOrder)
+	}
+
+	if c.Tables != nil {
+		config.IncludeTables = c.Tables.Include
+		config.ExcludeTables = c.Tables.Exclude
+	}
+
+	if c.Naming != nil {
+		setString(&config.CreatedAtColumn, c.Naming.CreatedAt)
+		setString(&config.UpdatedAtColumn, c.Naming.UpdatedAt)
+		setString(&config.DeletedAtColumn, c.Naming.DeletedAt)
+		setString(&config.VersionColumn, c.Naming.Version)
+
+		if len(c.Naming.Initialisms) > 0 {
+			config.Initialisms = c.Naming.Initialisms
+		}
+	}
+
+	if c.Features != nil {
+		if disabled(c.Features.Timestamps) {
+			config.CreatedAtColumn = ""
+			config.UpdatedAtColumn = ""
+		}
+
+		if disabled(c.Features.SoftDelete) {
+			config.DeletedAtColumn = ""
+		}
+
+		if disabled(c.Features.OptimisticLock) {
+			config.VersionColumn = ""
+		}
+	}
+
+	if len(c.Types) > 0 {
+		config.TypeOverrides = c.Types
+	}
+}
+
+func setString(dst *string, v *string) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func disabled(v *bool) bool {
+	return v != nil && !*v
+}
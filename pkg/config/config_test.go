@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/euskadi31/entify/pkg/builder"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+
+	filename := filepath.Join(dir, name)
+
+	assert.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+
+	return filename
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+
+	filename, err := Find(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "", filename)
+
+	writeFile(t, dir, "entify.hcl", "")
+	expected := writeFile(t, dir, "entify.yaml", "")
+
+	filename, err = Find(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, filename)
+}
+
+func TestLoadYAML(t *testing.T) {
+	filename := writeFile(t, t.TempDir(), "entify.yaml", `
+output: ./internal/entity
+package: model
+provider: mysql
+specs:
+  - schema/*.hcl
+nullable: pointer
+column_order: name
+tables:
+  exclude:
+    - schema_migrations
+naming:
+  deleted_at: removed_at
+  initialisms:
+    - api
+features:
+  optimistic_lock: false
+types:
+  - column: posts.tags
+    type: "[]string"
+`)
+
+	cfg, err := Load(filename)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "./internal/entity", cfg.Output)
+	assert.Equal(t, "model", cfg.Package)
+	assert.Equal(t, "mysql", cfg.Provider)
+	assert.Equal(t, []string{"schema/*.hcl"}, cfg.Specs)
+
+	config := builder.DefaultConfig()
+
+	cfg.Apply(&config)
+
+	assert.Equal(t, builder.NullableModePointer, config.NullableMode)
+	assert.Equal(t, builder.ColumnOrderName, config.ColumnOrder)
+	assert.Equal(t, []string{"schema_migrations"}, config.ExcludeTables)
+	assert.Equal(t, "created_at", config.CreatedAtColumn)
+	assert.Equal(t, "removed_at", config.DeletedAtColumn)
+	assert.Equal(t, "", config.VersionColumn)
+	assert.Equal(t, []string{"api"}, config.Initialisms)
+	assert.Len(t, config.TypeOverrides, 1)
+}
+
+func TestLoadHCL(t *testing.T) {
+	filename := writeFile(t, t.TempDir(), "entify.hcl", `
+output = "./internal/entity"
+provider = "postgres"
+
+tables {
+  include = ["users", "posts"]
+}
+
+features {
+  timestamps = false
+}
+
+type {
+  sql_type = "uuid"
+  type = "uuid.UUID"
+  package = "github.com/google/uuid"
+}
+`)
+
+	cfg, err := Load(filename)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "./internal/entity", cfg.Output)
+	assert.Equal(t, "postgres", cfg.Provider)
+
+	config := builder.DefaultConfig()
+
+	cfg.Apply(&config)
+
+	assert.Equal(t, []string{"users", "posts"}, config.IncludeTables)
+	assert.Equal(t, "", config.CreatedAtColumn)
+	assert.Equal(t, "", config.UpdatedAtColumn)
+	assert.Equal(t, "deleted_at", config.DeletedAtColumn)
+	assert.Equal(t, "uuid.UUID", config.TypeOverrides[0].Type)
+}
+
+func TestLoadInvalidTypeOverride(t *testing.T) {
+	filename := writeFile(t, t.TempDir(), "entify.yaml", `
+types:
+  - column: tags
+    type: "[]string"
+`)
+
+	_, err := Load(filename)
+	assert.EqualError(t, err, "type override #0: column tags must be formatted as table.column")
+}
@@ -0,0 +1,171 @@
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// providerTypes holds the column types only supported by one provider.
+var providerTypes = map[string]string{
+	"tinyint":          "mysql",
+	"mediumint":        "mysql",
+	"tinytext":         "mysql",
+	"mediumtext":       "mysql",
+	"longtext":         "mysql",
+	"tinyblob":         "mysql",
+	"mediumblob":       "mysql",
+	"longblob":         "mysql",
+	"year":             "mysql",
+	"set":              "mysql",
+	"enum":             "mysql",
+	"jsonb":            "postgres",
+	"bytea":            "postgres",
+	"smallserial":      "postgres",
+	"serial":           "postgres",
+	"bigserial":        "postgres",
+	"timestamptz":      "postgres",
+	"timetz":           "postgres",
+	"int2":             "postgres",
+	"int4":             "postgres",
+	"int8":             "postgres",
+	"float4":           "postgres",
+	"float8":           "postgres",
+	"double_precision": "postgres",
+	"inet":             "postgres",
+	"cidr":             "postgres",
+	"macaddr":          "postgres",
+	"money":            "postgres",
+	"interval":         "postgres",
+	"tsvector":         "postgres",
+	"xml":              "postgres",
+}
+
+// providerAttributes holds the column attributes only supported by one provider.
+var providerAttributes = map[string]string{
+	"unsigned":       "mysql",
+	"auto_increment": "mysql",
+	"on_update":      "mysql",
+	"charset":        "mysql",
+}
+
+// Providers returns the sorted names of the supported providers.
+func (e *Loader) Providers() []string {
+	names := make([]string, 0, len(e.providers))
+
+	for name := range e.providers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Detect infers the provider of the spec, from the provider attribute of the entify block:
+//
+//	entify {
+//	  provider = "postgres"
+//	}
+//
+// or from the column types and attributes specific to a provider.
+func (e *Loader) Detect(b []byte) (string, error) {
+	return e.detect(b, nil)
+}
+
+func (e *Loader) detect(b []byte, sources []source) (string, error) {
+	f, diags := hclsyntax.ParseConfig(b, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", fmt.Errorf("parse spec failed: %w", mapDiagnostics(diags, sources))
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return "", fmt.Errorf("unexpected spec body %T", f.Body)
+	}
+
+	found := map[string]struct{}{}
+	sqlite := false
+
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "entify":
+			if attr, ok := block.Body.Attributes["provider"]; ok {
+				v, diags := attr.Expr.Value(nil)
+				if diags.HasErrors() || !v.Type().Equals(cty.String) {
+					return "", fmt.Errorf("entify provider must be a string")
+				}
+
+				return v.AsString(), nil
+			}
+		case "schema":
+			if len(block.Labels) > 0 && block.Labels[0] == "main" {
+				sqlite = true
+			}
+
+			detectAttributes(block.Body, found)
+		case "table":
+			detectAttributes(block.Body, found)
+
+			for _, column := range block.Body.Blocks {
+				if column.Type != "column" {
+					continue
+				}
+
+				detectAttributes(column.Body, found)
+
+				if attr, ok := column.Body.Attributes["type"]; ok {
+					if p, ok := providerTypes[typeName(attr.Expr)]; ok {
+						found[p] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	providers := make([]string, 0, len(found))
+
+	for p := range found {
+		providers = append(providers, p)
+	}
+
+	sort.Strings(providers)
+
+	switch {
+	case len(providers) == 1:
+		return providers[0], nil
+	case len(providers) > 1:
+		return "", fmt.Errorf("ambiguous provider (%s), set it with the entify block or the provider flag", strings.Join(providers, ", "))
+	case sqlite:
+		return "sqlite", nil
+	}
+
+	return "", fmt.Errorf("cannot detect provider, set it with the entify block or the provider flag (%s)", strings.Join(e.Providers(), ", "))
+}
+
+func detectAttributes(body *hclsyntax.Body, found map[string]struct{}) {
+	for name := range body.Attributes {
+		if p, ok := providerAttributes[name]; ok {
+			found[p] = struct{}{}
+		}
+	}
+}
+
+// typeName returns the name of a column type expression: int, varchar(255) or enum("a", "b").
+func typeName(expr hclsyntax.Expression) string {
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		// references such as enum.status are not type names.
+		if len(e.Traversal) == 1 {
+			return e.Traversal.RootName()
+		}
+	case *hclsyntax.FunctionCallExpr:
+		return e.Name
+	}
+
+	return ""
+}
@@ -0,0 +1,98 @@
+package spec
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoaderDetect(t *testing.T) {
+	l := New()
+
+	for _, item := range []struct {
+		spec     string
+		expected string
+	}{
+		{
+			spec: `
+entify {
+  provider = "postgres"
+}
+
+table "users" {
+  column "id" {
+    type = int
+  }
+}
+`,
+			expected: "postgres",
+		},
+		{
+			spec: `
+table "users" {
+  column "id" {
+    type = jsonb
+  }
+  column "status" {
+    type = enum.status
+  }
+}
+`,
+			expected: "postgres",
+		},
+		{
+			spec: `
+table "users" {
+  column "status" {
+    type = enum("a", "b")
+  }
+}
+`,
+			expected: "mysql",
+		},
+		{
+			spec: `
+table "users" {
+  column "id" {
+    type = int
+  }
+}
+
+schema "main" {
+}
+`,
+			expected: "sqlite",
+		},
+	} {
+		provider, err := l.Detect([]byte(item.spec))
+		assert.NoError(t, err)
+		assert.Equal(t, item.expected, provider)
+	}
+
+	b, err := os.ReadFile("../../demo/atlas.hcl")
+	assert.NoError(t, err)
+
+	provider, err := l.Detect(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "mysql", provider)
+
+	_, err = l.Detect([]byte(`
+table "users" {
+  column "id" {
+    type = int
+  }
+}
+`))
+	assert.EqualError(t, err, "cannot detect provider, set it with the entify block or the provider flag (mariadb, mysql, postgres, sqlite)")
+
+	_, err = l.Detect([]byte(`
+table "users" {
+  column "id" {
+    type     = bigserial
+    unsigned = true
+  }
+}
+`))
+	assert.EqualError(t, err, "ambiguous provider (mysql, postgres), set it with the entify block or the provider flag")
+}
@@ -0,0 +1,62 @@
+package spec
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoaderInspect(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "demo.db")
+
+	db, err := sql.Open("sqlite3", filename)
+	assert.NoError(t, err)
+
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE users (
+		id integer PRIMARY KEY,
+		email varchar(90) NOT NULL,
+		created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	assert.NoError(t, err)
+
+	realm, b, err := New().Inspect(context.Background(), "sqlite://"+filename)
+	assert.NoError(t, err)
+
+	assert.Len(t, realm.Schemas, 1)
+	assert.Len(t, realm.Schemas[0].Tables, 1)
+	assert.Equal(t, "users", realm.Schemas[0].Tables[0].Name)
+	assert.Len(t, realm.Schemas[0].Tables[0].Columns, 3)
+	assert.Contains(t, string(b), `table "users"`)
+}
+
+func TestLoaderInspectKeylessTable(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "demo.db")
+
+	db, err := sql.Open("sqlite3", filename)
+	assert.NoError(t, err)
+
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE users (id integer PRIMARY KEY, email varchar(90) NOT NULL)`)
+	assert.NoError(t, err)
+
+	_, err = db.Exec(`CREATE TABLE logs (msg text NOT NULL)`)
+	assert.NoError(t, err)
+
+	realm, b, err := New().Inspect(context.Background(), "sqlite://"+filename)
+	assert.NoError(t, err)
+
+	assert.Len(t, realm.Schemas[0].Tables, 2)
+	assert.Contains(t, string(b), `table "logs"`)
+
+	assert.Equal(t, []string{"main.logs"}, DropKeylessTables(&realm))
+
+	assert.Len(t, realm.Schemas[0].Tables, 1)
+	assert.Equal(t, "users", realm.Schemas[0].Tables[0].Name)
+}
@@ -1,13 +1,20 @@
 package spec
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"ariga.io/atlas/schema/schemaspec"
 	"ariga.io/atlas/sql/mysql"
 	"ariga.io/atlas/sql/postgres"
 	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlite"
+	"github.com/hashicorp/hcl/v2"
 )
 
 type Loader struct {
@@ -20,30 +27,173 @@ func New() *Loader {
 			"mysql":    mysql.UnmarshalHCL,
 			"mariadb":  mysql.UnmarshalHCL,
 			"postgres": postgres.UnmarshalHCL,
+			"sqlite":   sqlite.UnmarshalHCL,
 		},
 	}
 }
 
-func (e *Loader) Parse(driver string, b []byte) (schema.Schema, error) {
+// Parse parses the HCL spec of the driver, the driver is detected from the spec when empty.
+func (e *Loader) Parse(driver string, b []byte) (schema.Realm, error) {
+	return e.parse(driver, b, nil)
+}
+
+func (e *Loader) parse(driver string, b []byte, sources []source) (schema.Realm, error) {
+	if driver == "" {
+		detected, err := e.detect(b, sources)
+		if err != nil {
+			return schema.Realm{}, fmt.Errorf("detect driver failed: %w", err)
+		}
+
+		driver = detected
+	}
+
 	d, ok := e.providers[driver]
 	if !ok {
-		return schema.Schema{}, fmt.Errorf("driver %s not supported", driver)
+		return schema.Realm{}, fmt.Errorf("driver %s not supported (%s)", driver, strings.Join(e.Providers(), ", "))
 	}
 
-	var spec schema.Schema
+	var spec schema.Realm
 
 	if err := d(b, &spec); err != nil {
-		return schema.Schema{}, fmt.Errorf("unmarshal spec failed: %w", err)
+		return schema.Realm{}, fmt.Errorf("unmarshal spec failed: %w", mapDiagnostics(err, sources))
 	}
 
 	return spec, nil
 }
 
-func (e *Loader) ParseFile(driver string, file string) (schema.Schema, error) {
-	b, err := os.ReadFile(file)
+// ParseFile parses a single HCL spec file.
+func (e *Loader) ParseFile(driver string, file string) (schema.Realm, error) {
+	return e.ParseFiles(driver, file)
+}
+
+// ParseFiles parses the HCL spec split across files, directories or glob patterns,
+// the files are merged so a table can reference a table or a schema of another file,
+// the HCL diagnostics still point to the file and the line of the error.
+func (e *Loader) ParseFiles(driver string, paths ...string) (schema.Realm, error) {
+	files, err := expandPaths(paths)
 	if err != nil {
-		return schema.Schema{}, fmt.Errorf("read spec file failed: %w", err)
+		return schema.Realm{}, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	sources := make([]source, 0, len(files))
+	line := 1
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return schema.Realm{}, fmt.Errorf("read spec file failed: %w", err)
+		}
+
+		sources = append(sources, source{
+			filename: file,
+			line:     line,
+			byte:     buf.Len(),
+		})
+
+		buf.Write(b)
+		buf.WriteString("\n")
+
+		line += bytes.Count(b, []byte("\n")) + 1
+	}
+
+	return e.parse(driver, buf.Bytes(), sources)
+}
+
+// source is a spec file merged in the parsed content, starting at line and byte.
+type source struct {
+	filename string
+	line     int
+	byte     int
+}
+
+// mapDiagnostics moves the ranges of the HCL diagnostics of err from the merged content
+// back to the spec files, so the errors point to the file and the line to fix.
+func mapDiagnostics(err error, sources []source) error {
+	var diags hcl.Diagnostics
+
+	if len(sources) == 0 || !errors.As(err, &diags) {
+		return err
+	}
+
+	for _, diag := range diags {
+		for _, r := range []*hcl.Range{diag.Subject, diag.Context} {
+			if r == nil || r.Filename != "" {
+				continue
+			}
+
+			src := sources[0]
+
+			for _, s := range sources {
+				if s.line <= r.Start.Line {
+					src = s
+				}
+			}
+
+			r.Filename = src.filename
+			r.Start.Line -= src.line - 1
+			r.End.Line -= src.line - 1
+			r.Start.Byte -= src.byte
+			r.End.Byte -= src.byte
+		}
+	}
+
+	return err
+}
+
+// expandPaths returns the sorted HCL files of the paths, a path being a file,
+// a directory (its *.hcl files) or a glob pattern.
+func expandPaths(paths []string) ([]string, error) {
+	files := []string{}
+	seen := map[string]struct{}{}
+
+	add := func(file string) {
+		if _, ok := seen[file]; !ok {
+			files = append(files, file)
+			seen[file] = struct{}{}
+		}
+	}
+
+	for _, p := range paths {
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec pattern %s: %w", p, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no spec file found for %s", p)
+		}
+
+		sort.Strings(matches)
+
+		for _, match := range matches {
+			fi, err := os.Stat(match)
+			if err != nil {
+				return nil, fmt.Errorf("stat %s failed: %w", match, err)
+			}
+
+			if !fi.IsDir() {
+				add(match)
+
+				continue
+			}
+
+			children, err := filepath.Glob(filepath.Join(match, "*.hcl"))
+			if err != nil {
+				return nil, fmt.Errorf("list %s dir failed: %w", match, err)
+			}
+
+			sort.Strings(children)
+
+			for _, child := range children {
+				add(child)
+			}
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no spec file found in %s", strings.Join(paths, ", "))
 	}
 
-	return e.Parse(driver, b)
+	return files, nil
 }
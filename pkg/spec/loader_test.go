@@ -0,0 +1,100 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPaths(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "tables"), 0755))
+
+	for _, file := range []string{"schema.hcl", "tables/users.hcl", "tables/posts.hcl", "tables/README.md"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, 0600))
+	}
+
+	files, err := expandPaths([]string{
+		filepath.Join(dir, "schema.hcl"),
+		filepath.Join(dir, "tables"),
+		filepath.Join(dir, "tables", "*.hcl"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "schema.hcl"),
+		filepath.Join(dir, "tables", "posts.hcl"),
+		filepath.Join(dir, "tables", "users.hcl"),
+	}, files)
+
+	_, err = expandPaths([]string{filepath.Join(dir, "missing.hcl")})
+	assert.Error(t, err)
+}
+
+func TestLoaderParseSQLite(t *testing.T) {
+	realm, err := New().Parse("sqlite", []byte(`
+table "users" {
+  schema = schema.main
+
+  column "id" {
+    null = false
+    type = integer
+  }
+
+  column "email" {
+    null = false
+    type = varchar(90)
+  }
+
+  column "created_at" {
+    null = true
+    type = datetime
+  }
+
+  primary_key {
+    columns = [column.id]
+  }
+}
+
+schema "main" {
+}
+`))
+	assert.NoError(t, err)
+
+	assert.Len(t, realm.Schemas, 1)
+	assert.Equal(t, "main", realm.Schemas[0].Name)
+
+	users, ok := realm.Schemas[0].Table("users")
+	assert.True(t, ok)
+	assert.Len(t, users.Columns, 3)
+	assert.IsType(t, &schema.IntegerType{}, users.Columns[0].Type.Type)
+	assert.IsType(t, &schema.StringType{}, users.Columns[1].Type.Type)
+	assert.IsType(t, &schema.TimeType{}, users.Columns[2].Type.Type)
+	assert.True(t, users.Columns[2].Type.Null)
+	assert.Equal(t, "id", users.PrimaryKey.Parts[0].C.Name)
+}
+
+func TestLoaderParseFilesDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "schema.hcl"), []byte(`schema "main" {
+}
+`), 0600))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "users.hcl"), []byte(`table "users" {
+  schema = schema.main
+
+  column "id" {
+    null = false
+    type = integer
+`), 0600))
+
+	for _, driver := range []string{"sqlite", ""} {
+		_, err := New().ParseFiles(driver, filepath.Join(dir, "*.hcl"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), filepath.Join(dir, "users.hcl")+":4,")
+	}
+}
@@ -2,5 +2,5 @@ package template
 
 import "embed"
 
-//go:embed *.go.tmpl predicate/*.go.tmpl __entity-package__/*.go.tmpl
+//go:embed *.go.tmpl predicate/*.go.tmpl internal/*.go.tmpl __entity-package__/*.go.tmpl partials/*.tmpl
 var files embed.FS
@@ -4,11 +4,18 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"sort"
+	"strings"
 	"text/template"
 )
 
+// partialsDir holds the templates of the shared {{define}} partials, they are not rendered.
+const partialsDir = "partials/"
+
 type Engine struct {
 	templates map[string]*template.Template
+	embedded  map[string]struct{}
+	funcs     template.FuncMap
 }
 
 func Must(e *Engine, err error) *Engine {
@@ -19,30 +26,56 @@ func Must(e *Engine, err error) *Engine {
 	return e
 }
 
-func New() (*Engine, error) {
+// New returns the engine of the embedded templates, the templates of the overrides
+// replace the embedded ones with the same path or are added as extra templates.
+// The partials of the partials dir are shared by all the templates, funcs are available
+// in the templates with the builtin ones, e.g. the naming functions of the builder.
+func New(funcs template.FuncMap, overrides ...fs.FS) (*Engine, error) {
 	e := &Engine{
 		templates: make(map[string]*template.Template),
+		embedded:  make(map[string]struct{}),
+		funcs:     builtins(),
+	}
+
+	for name, fn := range funcs {
+		e.funcs[name] = fn
 	}
 
-	if err := e.load(); err != nil {
+	sources := map[string]fs.FS{}
+
+	if err := collect(files, sources); err != nil {
+		return nil, err
+	}
+
+	for name := range sources {
+		e.embedded[name] = struct{}{}
+	}
+
+	for _, fsys := range overrides {
+		if err := collect(fsys, sources); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := e.load(sources); err != nil {
 		return nil, err
 	}
 
 	return e, nil
 }
 
-func (e *Engine) load() error {
-	err := fs.WalkDir(files, ".", func(path string, d fs.DirEntry, err error) error {
-		if d.IsDir() {
-			return nil
+// collect indexes the templates of fsys by path, replacing the ones already collected.
+func collect(fsys fs.FS, sources map[string]fs.FS) error {
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
 		}
 
-		pt, serr := template.ParseFS(files, path)
-		if serr != nil {
-			return fmt.Errorf("template parse fs: %w", serr)
+		if d.IsDir() || !strings.HasSuffix(path, ".tmpl") {
+			return nil
 		}
 
-		e.templates[path] = pt
+		sources[path] = fsys
 
 		return nil
 	})
@@ -53,6 +86,78 @@ func (e *Engine) load() error {
 	return nil
 }
 
+// load parses the partials in a base template cloned by each template.
+func (e *Engine) load(sources map[string]fs.FS) error {
+	names := make([]string, 0, len(sources))
+
+	for name := range sources {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	base := template.New("").Funcs(e.funcs)
+
+	for _, name := range names {
+		if !strings.HasPrefix(name, partialsDir) {
+			continue
+		}
+
+		if err := parse(base.New(name), sources[name], name); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range names {
+		if strings.HasPrefix(name, partialsDir) {
+			continue
+		}
+
+		t, err := base.Clone()
+		if err != nil {
+			return fmt.Errorf("clone partials failed: %w", err)
+		}
+
+		pt := t.New(name)
+
+		if err := parse(pt, sources[name], name); err != nil {
+			return err
+		}
+
+		e.templates[name] = pt
+	}
+
+	return nil
+}
+
+func parse(t *template.Template, fsys fs.FS, name string) error {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("read %s template failed: %w", name, err)
+	}
+
+	if _, err := t.Parse(string(b)); err != nil {
+		return fmt.Errorf("template parse fs: %w", err)
+	}
+
+	return nil
+}
+
+// Extras returns the sorted names of the templates added by the overrides.
+func (e *Engine) Extras() []string {
+	names := []string{}
+
+	for name := range e.templates {
+		if _, ok := e.embedded[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 func (e *Engine) ExecuteTemplate(wr io.Writer, name string, data interface{}) error {
 	if t, ok := e.templates[name]; ok {
 		//nolint: wrapcheck
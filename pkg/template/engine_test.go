@@ -0,0 +1,94 @@
+package template
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+	"text/template"
+
+	"github.com/euskadi31/entify/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngineOverrides(t *testing.T) {
+	e, err := New(nil, fstest.MapFS{
+		"client.go.tmpl": &fstest.MapFile{
+			Data: []byte("package {{.}}\n"),
+		},
+		"graphql/__entity-file__.graphql.tmpl": &fstest.MapFile{
+			Data: []byte("type {{.}} {}\n"),
+		},
+		"README.md": &fstest.MapFile{
+			Data: []byte("not a template"),
+		},
+	})
+	assert.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+
+	assert.NoError(t, e.ExecuteTemplate(buf, "client.go.tmpl", "entity"))
+	assert.Equal(t, "package entity\n", buf.String())
+
+	assert.Equal(t, []string{"graphql/__entity-file__.graphql.tmpl"}, e.Extras())
+}
+
+func TestEngineWithoutOverrides(t *testing.T) {
+	e, err := New(nil)
+	assert.NoError(t, err)
+
+	assert.Empty(t, e.Extras())
+	assert.EqualError(t, e.ExecuteTemplate(bytes.NewBuffer(nil), "unknown.go.tmpl", nil), "template unknown.go.tmpl not found")
+}
+
+func TestEnginePartials(t *testing.T) {
+	e, err := New(nil, fstest.MapFS{
+		"partials/receiver.tmpl": &fstest.MapFile{
+			Data: []byte(`{{define "receiver"}}{{.ReceiverVarName}} *{{.StructName}}{{end}}`),
+		},
+		"__entity-file___name.go.tmpl": &fstest.MapFile{
+			Data: []byte(`{{template "header"}}
+func ({{template "receiver" .}}) HasEmail() bool { return {{hasField . "email"}} }
+`),
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"__entity-file___name.go.tmpl"}, e.Extras())
+
+	buf := bytes.NewBuffer(nil)
+
+	assert.NoError(t, e.ExecuteTemplate(buf, "__entity-file___name.go.tmpl", &types.Entity{
+		ReceiverVarName: "u",
+		StructName:      "User",
+		Fields: []*types.Field{
+			{
+				Name: "email",
+			},
+		},
+	}))
+
+	assert.Equal(t, "// Code generated by entify, DO NOT EDIT.\nfunc (u *User) HasEmail() bool { return true }\n", buf.String())
+}
+
+func TestEngineFuncs(t *testing.T) {
+	overrides := fstest.MapFS{
+		"name.tmpl": &fstest.MapFile{
+			Data: []byte(`{{upper (structName .)}}`),
+		},
+	}
+
+	_, err := New(nil, overrides)
+	assert.Error(t, err)
+
+	e, err := New(template.FuncMap{
+		"structName": func(s string) string {
+			return s + "_struct"
+		},
+	}, overrides)
+	assert.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+
+	assert.NoError(t, e.ExecuteTemplate(buf, "name.tmpl", "user"))
+	assert.Equal(t, "USER_STRUCT", buf.String())
+}
@@ -0,0 +1,35 @@
+package template
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/euskadi31/entify/pkg/types"
+)
+
+// builtins returns the functions available in all the templates, see New for the other ones.
+func builtins() template.FuncMap {
+	return template.FuncMap{
+		"hasField": hasField,
+		"field":    field,
+		"lower":    strings.ToLower,
+		"upper":    strings.ToUpper,
+		"join":     strings.Join,
+	}
+}
+
+// hasField reports whether the entity has a field for the column.
+func hasField(entity *types.Entity, name string) bool {
+	return field(entity, name) != nil
+}
+
+// field returns the field of the column, nil when there is none.
+func field(entity *types.Entity, name string) *types.Field {
+	for _, f := range entity.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+
+	return nil
+}
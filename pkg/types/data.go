@@ -14,9 +14,22 @@ type Entity struct {
 	StructName         string
 	VariableName       string
 	Imports            []string
+	SQLImports         []string
+	PackageImports     []string
 	Fields             []*Field
+	Defaults           []*Field
+	Enums              []*Field
+	JSONFields         []*Field
+	JSONImports        []string
+	TypedJSON          bool
+	CreatedAt          *Field
+	UpdatedAt          *Field
+	DeletedAt          *Field
+	Version            *Field
 	FieldsCount        int
 	PrimaryKeys        []*Field
+	PrimaryKeyImports  []string
+	ClientImports      []string
 	PrimaryKeyAutoIncr bool
 }
 
@@ -29,6 +42,10 @@ const (
 	FieldTypeKindBool
 	FieldTypeKindDate
 	FieldTypeKindJson
+	FieldTypeKindBytes
+	FieldTypeKindUUID
+	FieldTypeKindNetwork
+	FieldTypeKindArray
 )
 
 type Field struct {
@@ -37,10 +54,25 @@ type Field struct {
 	VariableName           string
 	TypeKind               FieldTypeKind
 	Type                   string
+	LocalType              string
+	StructType             string
 	SQLType                string
 	Nullable               bool
+	Pointer                bool
+	SQLNull                bool
 	NullableSQLAccessValue string
 	DefaultValue           string
+	ScanValue              string
+	SQLValue               string
+	Default                string
+	DefaultFunc            bool
+	JSON                   bool
+	EnumValues             []*EnumValue
+}
+
+type EnumValue struct {
+	Name  string
+	Value string
 }
 
 type DataEntity struct {